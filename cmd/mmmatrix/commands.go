@@ -0,0 +1,496 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"matrix/mdm"
+)
+
+var errHelp = flag.ErrHelp
+
+// newFlagSet создаёт набор флагов подкоманды; ошибки разбора печатает run
+func newFlagSet(name string, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() { fmt.Fprintln(fs.Output(), strings.TrimSpace("Usage: mmmatrix "+name+" [flags] "+args)) }
+	return fs
+}
+
+// typeFlag добавляет флаг -type, читаемый elementType
+func typeFlag(fs *flag.FlagSet) {
+	fs.String("type", "", "element type: int8…uint64, float32, float64, complex64, complex128 (default: from binary or npy input, else float64)")
+}
+
+// parseFlags разбирает флаги; при -h печатает их описание в stdout
+func parseFlags(fs *flag.FlagSet, args []string, e *env) error {
+	err := fs.Parse(args)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		fs.SetOutput(e.stdout)
+		fs.Usage()
+		fs.PrintDefaults()
+		return errHelp
+	case err != nil:
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	return nil
+}
+
+// outputFlags добавляет флаги -o и -to
+func outputFlags(fs *flag.FlagSet) *output {
+	o := &output{}
+	fs.StringVar(&o.path, "o", "", "output file (default: standard output)")
+	fs.StringVar(&o.format, "to", "", "output format: binary, npy, json, json-nested, csv")
+	return o
+}
+
+// lambdaMuFlags добавляет флаги -lambda и -mu
+func lambdaMuFlags(fs *flag.FlagSet) (*uint, *uint) {
+	return fs.Uint("lambda", 0, "number of Scott (scalar) indices λ"),
+		fs.Uint("mu", 1, "number of Cayley (summed) indices μ")
+}
+
+// uint32Flag связывает флаг типа uint с полем конфигурации типа uint32
+type uint32Flag struct {
+	name string
+	src  *uint
+	dst  *uint32
+}
+
+// setUint32 проверяет значения флагов и копирует их в поля конфигурации
+func setUint32(flags ...uint32Flag) error {
+	for _, f := range flags {
+		if *f.src > math.MaxUint32 {
+			return fmt.Errorf("%w: -%s %d is too large", errUsage, f.name, *f.src)
+		}
+		*f.dst = uint32(*f.src)
+	}
+	return nil
+}
+
+// multiplyConfig — параметры подкоманды multiply
+type multiplyConfig struct {
+	lambda, mu uint32
+	sequential bool
+	workers    int
+	timeout    time.Duration
+	progress   bool
+	out        output
+}
+
+func multiplyCommand(args []string, e *env) error {
+	fs := newFlagSet("multiply", "A B")
+	typeFlag(fs)
+	lambda, mu := lambdaMuFlags(fs)
+	var cfg multiplyConfig
+	fs.BoolVar(&cfg.sequential, "sequential", false, "multiply in a single goroutine")
+	fs.IntVar(&cfg.workers, "workers", 0, "number of worker goroutines (default: number of CPUs)")
+	fs.DurationVar(&cfg.timeout, "timeout", 0, "abort the multiplication after this duration")
+	fs.BoolVar(&cfg.progress, "progress", false, "show a progress bar on standard error")
+	out := outputFlags(fs)
+	if err := parseFlags(fs, args, e); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("%w: expected 2 input files, got %d", errUsage, fs.NArg())
+	}
+	if err := setUint32(uint32Flag{"lambda", lambda, &cfg.lambda}, uint32Flag{"mu", mu, &cfg.mu}); err != nil {
+		return err
+	}
+	cfg.out = *out
+
+	sources, err := openSources(fs.Args(), e)
+	if err != nil {
+		return err
+	}
+	defer closeSources(sources)
+	set, err := commandsFor(elementType(fs, sources))
+	if err != nil {
+		return err
+	}
+	return set.multiply(cfg, sources, e)
+}
+
+func generateCommand(args []string, e *env) error {
+	fs := newFlagSet("generate", "")
+	typeFlag(fs)
+	var cfg generateConfig
+	fs.StringVar(&cfg.kind, "kind", "random", "matrix kind: random, zero, identity")
+	x := fs.Uint("x", 3, "length of every axis")
+	p := fs.Uint("p", 2, "order of the matrix")
+	shape := fs.String("shape", "", "comma-separated axis lengths for a non-cubic matrix, e.g. 2,3,4")
+	lambda, mu := lambdaMuFlags(fs)
+	fs.BoolVar(&cfg.left, "left", false, "generate the left identity instead of the right one")
+	fs.Uint64Var(&cfg.seed, "seed", 1, "random seed")
+	out := outputFlags(fs)
+	if err := parseFlags(fs, args, e); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected arguments %q", errUsage, fs.Args())
+	}
+	err := setUint32(uint32Flag{"x", x, &cfg.x}, uint32Flag{"p", p, &cfg.p},
+		uint32Flag{"lambda", lambda, &cfg.lambda}, uint32Flag{"mu", mu, &cfg.mu})
+	if err != nil {
+		return err
+	}
+	if *shape != "" {
+		for _, field := range strings.Split(*shape, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil || n < 0 {
+				return fmt.Errorf("%w: invalid -shape %q", errUsage, *shape)
+			}
+			cfg.shape = append(cfg.shape, n)
+		}
+	}
+	switch cfg.kind {
+	case "random", "zero":
+	case "identity":
+		if cfg.shape != nil {
+			return fmt.Errorf("%w: identity matrices are cubic, use -x and -p", errUsage)
+		}
+	default:
+		return fmt.Errorf("%w: unknown -kind %q", errUsage, cfg.kind)
+	}
+	cfg.out = *out
+
+	set, err := commandsFor(elementType(fs, nil))
+	if err != nil {
+		return err
+	}
+	return set.generate(cfg, e)
+}
+
+func infoCommand(args []string, e *env) error {
+	return withSource("info", args, e, nil, func(set commandSet, s *source) error {
+		return set.info(s, e)
+	})
+}
+
+func convertCommand(args []string, e *env) error {
+	var out *output
+	return withSource("convert", args, e, func(fs *flag.FlagSet) { out = outputFlags(fs) }, func(set commandSet, s *source) error {
+		return set.convert(s, *out, e)
+	})
+}
+
+// withSource разбирает флаги подкоманды с одним входом, открывает его
+// и вызывает f для выбранного типа элементов
+func withSource(name string, args []string, e *env, flags func(*flag.FlagSet), f func(commandSet, *source) error) error {
+	fs := newFlagSet(name, "FILE")
+	typeFlag(fs)
+	if flags != nil {
+		flags(fs)
+	}
+	if err := parseFlags(fs, args, e); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: expected 1 input file, got %d", errUsage, fs.NArg())
+	}
+	s, err := openSource(fs.Arg(0), e)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	set, err := commandsFor(elementType(fs, []*source{s}))
+	if err != nil {
+		return err
+	}
+	return f(set, s)
+}
+
+func verifyCommand(args []string, e *env) error {
+	fs := newFlagSet("verify", "[A B]")
+	typeFlag(fs)
+	lambda, mu := lambdaMuFlags(fs)
+	var cfg verifyConfig
+	x := fs.Uint("x", 3, "axis length of generated operands")
+	p := fs.Uint("p", 2, "order of generated operands")
+	fs.Uint64Var(&cfg.seed, "seed", 1, "random seed for generated operands")
+	fs.IntVar(&cfg.workers, "workers", 0, "number of worker goroutines (default: number of CPUs)")
+	if err := parseFlags(fs, args, e); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 && fs.NArg() != 2 {
+		return fmt.Errorf("%w: expected 0 or 2 input files, got %d", errUsage, fs.NArg())
+	}
+	err := setUint32(uint32Flag{"lambda", lambda, &cfg.lambda}, uint32Flag{"mu", mu, &cfg.mu},
+		uint32Flag{"x", x, &cfg.x}, uint32Flag{"p", p, &cfg.p})
+	if err != nil {
+		return err
+	}
+
+	sources, err := openSources(fs.Args(), e)
+	if err != nil {
+		return err
+	}
+	defer closeSources(sources)
+	set, err := commandsFor(elementType(fs, sources))
+	if err != nil {
+		return err
+	}
+	return set.verify(cfg, sources, e)
+}
+
+// elementType возвращает тип элементов из флага -type, заголовка первого
+// двоичного входа или входа .npy, иначе float64
+func elementType(fs *flag.FlagSet, sources []*source) string {
+	if typ := fs.Lookup("type").Value.String(); typ != "" {
+		return typ
+	}
+	for _, s := range sources {
+		switch {
+		case s.header != nil:
+			return s.header.Kind.String()
+		case s.npyHeader != nil:
+			return s.npyHeader.Kind.String()
+		}
+	}
+	return "float64"
+}
+
+// commandSet — реализации подкоманд для одного типа элементов
+type commandSet interface {
+	multiply(cfg multiplyConfig, sources []*source, e *env) error
+	generate(cfg generateConfig, e *env) error
+	info(s *source, e *env) error
+	convert(s *source, out output, e *env) error
+	verify(cfg verifyConfig, sources []*source, e *env) error
+}
+
+// commandsFor возвращает реализации подкоманд для типа элементов typ
+func commandsFor(typ string) (commandSet, error) {
+	switch typ {
+	case "int8":
+		return typed[int8]{}, nil
+	case "int16":
+		return typed[int16]{}, nil
+	case "int32":
+		return typed[int32]{}, nil
+	case "int64":
+		return typed[int64]{}, nil
+	case "uint8":
+		return typed[uint8]{}, nil
+	case "uint16":
+		return typed[uint16]{}, nil
+	case "uint32":
+		return typed[uint32]{}, nil
+	case "uint64":
+		return typed[uint64]{}, nil
+	case "float32":
+		return typed[float32]{}, nil
+	case "float64":
+		return typed[float64]{}, nil
+	case "complex64":
+		return typed[complex64]{}, nil
+	case "complex128":
+		return typed[complex128]{}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown element type %q", errUsage, typ)
+}
+
+// typed реализует подкоманды для элементов типа T
+type typed[T mdm.Number] struct{}
+
+func (typed[T]) multiply(cfg multiplyConfig, sources []*source, e *env) error {
+	lhs, err := load[T](sources[0])
+	if err != nil {
+		return err
+	}
+	rhs, err := load[T](sources[1])
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+	opts := &mdm.Options{Workers: cfg.workers}
+	if cfg.progress {
+		opts.Progress = progressBar(e.stderr)
+	}
+
+	var result *mdm.Matrix[T]
+	if cfg.sequential {
+		result, err = lhs.MultiplyWith(ctx, cfg.lambda, cfg.mu, rhs, opts)
+	} else {
+		result, err = lhs.ParallelMultiplyWith(ctx, cfg.lambda, cfg.mu, rhs, opts)
+	}
+	if err != nil {
+		return err
+	}
+	return save(result, cfg.out, e)
+}
+
+// progressBar возвращает обратный вызов Options.Progress, рисующий
+// индикатор выполнения в w
+func progressBar(w io.Writer) func(done, total int) {
+	const width = 40
+	return func(done, total int) {
+		filled := width
+		if total > 0 {
+			filled = int(int64(done) * width / int64(total))
+		}
+		fmt.Fprintf(w, "\r[%s%s] %3d%%", strings.Repeat("=", filled), strings.Repeat(" ", width-filled), filled*100/width)
+		if done == total {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// generateConfig — параметры подкоманды generate
+type generateConfig struct {
+	kind       string
+	x, p       uint32
+	shape      []int
+	lambda, mu uint32
+	left       bool
+	seed       uint64
+	out        output
+}
+
+func (typed[T]) generate(cfg generateConfig, e *env) error {
+	var m *mdm.Matrix[T]
+	var err error
+	switch {
+	case cfg.kind == "identity" && cfg.left:
+		m, err = mdm.LeftIdentity[T](cfg.x, cfg.p, cfg.lambda, cfg.mu)
+	case cfg.kind == "identity":
+		m, err = mdm.Identity[T](cfg.x, cfg.p, cfg.lambda, cfg.mu)
+	case cfg.shape != nil:
+		m, err = mdm.NewShaped[T](cfg.shape...)
+	default:
+		m, err = mdm.NewMatrix[T](cfg.x, cfg.p)
+	}
+	if err != nil {
+		return err
+	}
+	if cfg.kind == "random" {
+		fillRandom(m.Data, cfg.seed)
+	}
+	return save(m, cfg.out, e)
+}
+
+// fillRandom заполняет data небольшими случайными значениями: целыми из
+// [-9, 9] ([0, 9] для беззнаковых) или вещественными из [-1, 1)
+func fillRandom[T mdm.Number](data []T, seed uint64) {
+	rng := rand.New(rand.NewPCG(seed, 0))
+	for i := range data {
+		v := reflect.ValueOf(&data[i]).Elem()
+		switch v.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v.SetInt(rng.Int64N(19) - 9)
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v.SetUint(rng.Uint64N(10))
+		case reflect.Float32, reflect.Float64:
+			v.SetFloat(rng.Float64()*2 - 1)
+		default:
+			v.SetComplex(complex(rng.Float64()*2-1, rng.Float64()*2-1))
+		}
+	}
+}
+
+func (typed[T]) info(s *source, e *env) error {
+	m, err := load[T](s)
+	if err != nil {
+		return err
+	}
+	nonzero := 0
+	for _, v := range m.Data {
+		if v != 0 {
+			nonzero++
+		}
+	}
+	cubic := "no"
+	if m.IsCubic() {
+		cubic = fmt.Sprintf("yes (X=%d)", m.X)
+	}
+	fmt.Fprintf(e.stdout, "format:   %s\n", s.format)
+	fmt.Fprintf(e.stdout, "type:     %v\n", reflect.TypeFor[T]())
+	fmt.Fprintf(e.stdout, "order:    %d\n", m.P)
+	fmt.Fprintf(e.stdout, "shape:    %v\n", m.Shape())
+	fmt.Fprintf(e.stdout, "cubic:    %s\n", cubic)
+	fmt.Fprintf(e.stdout, "elements: %d\n", len(m.Data))
+	fmt.Fprintf(e.stdout, "nonzero:  %d\n", nonzero)
+	if s.header != nil {
+		fmt.Fprintf(e.stdout, "version:  %d\n", s.header.Version)
+		fmt.Fprintf(e.stdout, "bytes:    %v\n", s.header.ByteOrder)
+	}
+	return nil
+}
+
+func (typed[T]) convert(s *source, out output, e *env) error {
+	m, err := load[T](s)
+	if err != nil {
+		return err
+	}
+	return save(m, out, e)
+}
+
+// verifyConfig — параметры подкоманды verify
+type verifyConfig struct {
+	lambda, mu uint32
+	x, p       uint32
+	seed       uint64
+	workers    int
+}
+
+func (typed[T]) verify(cfg verifyConfig, sources []*source, e *env) error {
+	var lhs, rhs *mdm.Matrix[T]
+	var err error
+	if len(sources) == 2 {
+		if lhs, err = load[T](sources[0]); err != nil {
+			return err
+		}
+		if rhs, err = load[T](sources[1]); err != nil {
+			return err
+		}
+	} else {
+		if lhs, err = mdm.NewMatrix[T](cfg.x, cfg.p); err != nil {
+			return err
+		}
+		rhs, _ = mdm.NewMatrix[T](cfg.x, cfg.p)
+		fillRandom(lhs.Data, cfg.seed)
+		fillRandom(rhs.Data, cfg.seed+1)
+	}
+
+	sequential, err := lhs.MultiplyChecked(cfg.lambda, cfg.mu, rhs)
+	if err != nil {
+		return err
+	}
+	parallel, err := lhs.ParallelMultiplyWith(context.Background(), cfg.lambda, cfg.mu, rhs, &mdm.Options{Workers: cfg.workers})
+	if err != nil {
+		return err
+	}
+
+	differ := 0
+	for i := range sequential.Data {
+		if sequential.Data[i] != parallel.Data[i] {
+			differ++
+		}
+	}
+	if differ > 0 || !slices.Equal(sequential.Shape(), parallel.Shape()) {
+		fmt.Fprintf(e.stdout, "✗ Sequential and parallel results differ in %d of %d elements\n", differ, len(sequential.Data))
+		return errMismatch
+	}
+	fmt.Fprintf(e.stdout, "✓ Sequential and parallel results match (%d elements, shape %v)\n", len(sequential.Data), sequential.Shape())
+	return nil
+}
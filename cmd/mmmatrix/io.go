@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"matrix/mdm"
+)
+
+// Форматы хранения матриц
+const (
+	formatBinary = "binary"
+	formatNPY    = "npy"
+	formatJSON   = "json"
+	formatNested = "json-nested"
+	formatCSV    = "csv"
+)
+
+// source — открытый вход с определённым по содержимому форматом
+type source struct {
+	name   string
+	r      *bufio.Reader
+	closer io.Closer
+	format string
+
+	// data — вход для чтения данных: r или, для обычного файла, r с длиной
+	// непрочитанной части
+	data io.Reader
+
+	// Заголовок двоичного формата, прочитанный при открытии
+	header *mdm.Header
+	// Заголовок .npy, прочитанный при открытии
+	npyHeader *mdm.NPYHeader
+}
+
+// openSource открывает файл name или, для "-", стандартный ввод
+func openSource(name string, e *env) (*source, error) {
+	s := &source{name: name}
+	if name == "-" {
+		s.r = bufio.NewReader(e.stdin)
+	} else {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		s.r, s.closer = bufio.NewReader(f), f
+		if fi, err := f.Stat(); err == nil && fi.Mode().IsRegular() {
+			s.data = sizedReader{Reader: s.r, file: f, size: fi.Size()}
+		}
+	}
+	if s.data == nil {
+		s.data = s.r
+	}
+
+	magic, _ := s.r.Peek(6)
+	switch {
+	case bytes.HasPrefix(magic, []byte("MDMX")):
+		s.format = formatBinary
+		h, err := mdm.ReadHeader(s.r)
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		s.header = h
+	case string(magic) == "\x93NUMPY":
+		s.format = formatNPY
+		h, err := mdm.ReadNPYHeader(s.r)
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		s.npyHeader = h
+	default:
+		s.format = formatCSV
+		for {
+			b, err := s.r.Peek(1)
+			if err != nil || !isSpace(b[0]) {
+				if err == nil && (b[0] == '{' || b[0] == '[') {
+					s.format = formatJSON
+				}
+				break
+			}
+			s.r.ReadByte()
+		}
+	}
+	return s, nil
+}
+
+// sizedReader — буферизованный вход обычного файла, сообщающий через Len
+// длину непрочитанной части. По ней mdm выделяет память под данные сразу,
+// не дожидаясь их поступления
+type sizedReader struct {
+	*bufio.Reader
+	file *os.File
+	size int64
+}
+
+// Len возвращает число байтов файла, ещё не прочитанных из буфера
+func (r sizedReader) Len() int {
+	pos, err := r.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0
+	}
+	return int(r.size - pos + int64(r.Buffered()))
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// Close закрывает файл входа
+func (s *source) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// openSources открывает входы; не более одного из них может быть "-"
+func openSources(names []string, e *env) ([]*source, error) {
+	stdin := 0
+	for _, name := range names {
+		if name == "-" {
+			stdin++
+		}
+	}
+	if stdin > 1 {
+		return nil, fmt.Errorf("%w: standard input can be used only once", errUsage)
+	}
+	sources := make([]*source, 0, len(names))
+	for _, name := range names {
+		s, err := openSource(name, e)
+		if err != nil {
+			closeSources(sources)
+			return nil, err
+		}
+		sources = append(sources, s)
+	}
+	return sources, nil
+}
+
+func closeSources(sources []*source) {
+	for _, s := range sources {
+		s.Close()
+	}
+}
+
+// load читает матрицу из входа
+func load[T mdm.Number](s *source) (*mdm.Matrix[T], error) {
+	var m *mdm.Matrix[T]
+	var err error
+	switch s.format {
+	case formatBinary:
+		m, err = mdm.ReadData[T](s.data, s.header)
+	case formatNPY:
+		m, err = mdm.ReadNPYData[T](s.data, s.npyHeader)
+	case formatJSON:
+		var b []byte
+		if b, err = io.ReadAll(s.r); err == nil {
+			m = new(mdm.Matrix[T])
+			err = m.UnmarshalJSON(b)
+		}
+	default:
+		m, err = mdm.ReadCSV[T](s.r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.name, err)
+	}
+	return m, nil
+}
+
+// output описывает, куда и в каком формате записать результат
+type output struct {
+	path   string
+	format string
+}
+
+// resolve определяет формат выхода по флагу -to, расширению файла
+// или типу стандартного вывода
+func (o output) resolve(e *env) (string, error) {
+	switch o.format {
+	case formatBinary, formatNPY, formatJSON, formatNested, formatCSV:
+		return o.format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("%w: unknown format %q", errUsage, o.format)
+	}
+	switch strings.ToLower(filepath.Ext(o.path)) {
+	case ".npy":
+		return formatNPY, nil
+	case ".json":
+		return formatJSON, nil
+	case ".csv":
+		return formatCSV, nil
+	case ".mdm", ".bin":
+		return formatBinary, nil
+	}
+	if o.path == "" && e.stdoutTerminal {
+		return formatJSON, nil
+	}
+	return formatBinary, nil
+}
+
+// save записывает матрицу в файл или стандартный вывод. Файл, запись
+// которого не удалась, удаляется, чтобы не оставлять неполный результат
+func save[T mdm.Number](m *mdm.Matrix[T], o output, e *env) (err error) {
+	format, err := o.resolve(e)
+	if err != nil {
+		return err
+	}
+	w := e.stdout
+	if o.path != "" && o.path != "-" {
+		var f *os.File
+		if f, err = os.Create(o.path); err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(o.path)
+			}
+		}()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	if err := encode(bw, m, format); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func encode[T mdm.Number](w io.Writer, m *mdm.Matrix[T], format string) error {
+	switch format {
+	case formatBinary:
+		_, err := m.WriteTo(w)
+		return err
+	case formatNPY:
+		return mdm.SaveNPY(w, m)
+	case formatCSV:
+		return m.WriteCSV(w)
+	}
+	var b []byte
+	var err error
+	if format == formatNested {
+		b, err = m.MarshalNestedJSON()
+	} else {
+		b, err = json.Marshal(m)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
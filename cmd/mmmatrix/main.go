@@ -0,0 +1,120 @@
+// Команда mmmatrix выполняет (λ,μ)-умножение многомерных матриц,
+// создаёт матрицы и преобразует их между форматами хранения.
+//
+// Использование:
+//
+//	mmmatrix multiply [-lambda N] [-mu N] [-o FILE] A B
+//	mmmatrix generate [-kind random|zero|identity] [-x X -p P | -shape 2,3,4] [-o FILE]
+//	mmmatrix info FILE
+//	mmmatrix convert [-to FORMAT] [-o FILE] FILE
+//	mmmatrix verify [-lambda N] [-mu N] [A B]
+//	mmmatrix serve [-addr :8080] [-timeout 30s]
+//
+// Вместо имени файла можно указать "-" для стандартного ввода; без -o
+// результат пишется в стандартный вывод. Форматы: binary, npy, json,
+// json-nested, csv. Формат входа определяется по содержимому, формат
+// выхода — по -to, расширению файла или, для стандартного вывода, по тому,
+// подключён ли он к терминалу (json) или к конвейеру (binary).
+//
+// Коды возврата: 0 — успех, 1 — ошибка выполнения, 2 — неверные аргументы,
+// 3 — verify обнаружил расхождение результатов
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Коды возврата
+const (
+	exitOK       = 0
+	exitFailure  = 1
+	exitUsage    = 2
+	exitMismatch = 3
+)
+
+var (
+	errUsage    = errors.New("usage")
+	errMismatch = errors.New("sequential and parallel results differ")
+)
+
+// env — стандартные потоки команды; подменяются в тестах
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// stdoutTerminal сообщает, подключён ли stdout к терминалу
+	stdoutTerminal bool
+}
+
+func main() {
+	e := &env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	if info, err := os.Stdout.Stat(); err == nil {
+		e.stdoutTerminal = info.Mode()&os.ModeCharDevice != 0
+	}
+	os.Exit(run(os.Args[1:], e))
+}
+
+// commands сопоставляет подкомандам их реализации
+var commands = map[string]func(args []string, e *env) error{
+	"multiply": multiplyCommand,
+	"generate": generateCommand,
+	"info":     infoCommand,
+	"convert":  convertCommand,
+	"verify":   verifyCommand,
+	"serve":    serveCommand,
+}
+
+// run выполняет подкоманду и возвращает код возврата
+func run(args []string, e *env) int {
+	if len(args) == 0 {
+		usage(e.stderr)
+		return exitUsage
+	}
+	cmd, ok := commands[args[0]]
+	switch {
+	case args[0] == "help" || args[0] == "-h" || args[0] == "--help":
+		usage(e.stdout)
+		return exitOK
+	case !ok:
+		fmt.Fprintf(e.stderr, "mmmatrix: unknown command %q\n", args[0])
+		usage(e.stderr)
+		return exitUsage
+	}
+
+	err := cmd(args[1:], e)
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errHelp):
+		return exitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(e.stderr, "mmmatrix %s: %v\n", args[0], err)
+		return exitUsage
+	case errors.Is(err, errMismatch):
+		fmt.Fprintf(e.stderr, "mmmatrix %s: %v\n", args[0], err)
+		return exitMismatch
+	default:
+		fmt.Fprintf(e.stderr, "mmmatrix %s: %v\n", args[0], err)
+		return exitFailure
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprint(w, `Usage: mmmatrix <command> [flags] [files]
+
+Commands:
+  multiply  (λ,μ)-multiply two matrices
+  generate  create a random, zero or identity matrix
+  info      describe a matrix file
+  convert   convert a matrix between formats
+  verify    compare sequential and parallel multiplication
+  serve     run the HTTP/JSON multiplication service
+
+Use "-" for standard input. Formats: binary, npy, json, json-nested, csv.
+Run "mmmatrix <command> -h" for command flags.
+`)
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"matrix/mdm"
+)
+
+// runCLI выполняет команду с заданным stdin и возвращает код возврата и вывод
+func runCLI(t *testing.T, stdin []byte, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &env{stdin: bytes.NewReader(stdin), stdout: &stdout, stderr: &stderr})
+	return code, stdout.String(), stderr.String()
+}
+
+// TestPipeline тестирует связку подкоманд через файлы и стандартные потоки
+func TestPipeline(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.mdm")
+	b := filepath.Join(dir, "b.json")
+
+	if code, _, stderr := runCLI(t, nil, "generate", "-type", "int64", "-x", "2", "-o", a); code != exitOK {
+		t.Fatalf("generate: exit %d: %s", code, stderr)
+	}
+	if code, _, stderr := runCLI(t, nil, "generate", "-type", "int64", "-kind", "identity", "-x", "2", "-o", b); code != exitOK {
+		t.Fatalf("generate: exit %d: %s", code, stderr)
+	}
+
+	// Умножение на правую единицу возвращает левый сомножитель; тип
+	// элементов берётся из заголовка двоичного входа a
+	code, product, stderr := runCLI(t, nil, "multiply", "-lambda", "0", "-mu", "1", "-to", "json", a, b)
+	if code != exitOK {
+		t.Fatalf("multiply: exit %d: %s", code, stderr)
+	}
+	_, original, _ := runCLI(t, nil, "convert", "-to", "json", a)
+	if product != original {
+		t.Errorf("A × I = %s, expected %s", product, original)
+	}
+
+	// JSON читается со стандартного ввода; его тип задаётся флагом -type
+	code, info, stderr := runCLI(t, []byte(product), "info", "-type", "int64", "-")
+	if code != exitOK || !strings.Contains(info, "format:   json") || !strings.Contains(info, "shape:    [2 2]") {
+		t.Errorf("info: exit %d, output %q, stderr %q", code, info, stderr)
+	}
+	// Без -type тип элементов определяется по заголовку двоичного входа
+	_, info, _ = runCLI(t, nil, "info", a)
+	if !strings.Contains(info, "type:     int64") {
+		t.Errorf("info did not detect binary element type: %q", info)
+	}
+
+	_, csv, _ := runCLI(t, nil, "convert", "-to", "csv", a)
+	code, back, stderr := runCLI(t, []byte(csv), "convert", "-type", "int64", "-to", "json", "-")
+	if code != exitOK || back != original {
+		t.Errorf("CSV round trip: exit %d, got %q, expected %q (%s)", code, back, original, stderr)
+	}
+}
+
+// TestNPYInput тестирует определение типа элементов по dtype файла .npy
+func TestNPYInput(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.npy")
+	if code, _, stderr := runCLI(t, nil, "generate", "-type", "int32", "-x", "2", "-o", a); code != exitOK {
+		t.Fatalf("generate: exit %d: %s", code, stderr)
+	}
+	_, info, _ := runCLI(t, nil, "info", a)
+	if !strings.Contains(info, "format:   npy") || !strings.Contains(info, "type:     int32") {
+		t.Errorf("info did not detect npy element type: %q", info)
+	}
+	_, original, _ := runCLI(t, nil, "convert", "-to", "json", a)
+	_, converted, _ := runCLI(t, nil, "convert", "-type", "int32", "-to", "json", a)
+	if original != converted {
+		t.Errorf("npy without -type: got %q, expected %q", original, converted)
+	}
+}
+
+// TestFailedOutput тестирует, что при ошибке записи не остаётся неполного файла
+func TestFailedOutput(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.npy")
+	code, _, _ := runCLI(t, []byte(`{"shape":[2,3],"data":[1,2,3,4,5,6]}`), "convert", "-o", out, "-")
+	if code != exitFailure {
+		t.Errorf("Non-cubic npy: expected exit %d, got %d", exitFailure, code)
+	}
+	if _, err := os.Stat(out); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Partial output left behind: %v", err)
+	}
+}
+
+// TestLargeFile тестирует, что данные обычного файла читаются в память,
+// выделенную один раз по его длине
+func TestLargeFile(t *testing.T) {
+	dir := t.TempDir()
+	// Обе матрицы содержат 2^22 элементов float64
+	const payload = 8 << 22
+	m, err := mdm.NewShaped[float64](64, 1<<16)
+	if err != nil {
+		t.Fatalf("NewShaped: %v", err)
+	}
+	var binary, npy bytes.Buffer
+	if _, err := m.WriteTo(&binary); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if err := mdm.SaveNPY(&npy, mdm.CreateMatrix[float64](2048, 2)); err != nil {
+		t.Fatalf("SaveNPY: %v", err)
+	}
+	files := map[string][]byte{"large.mdm": binary.Bytes(), "large.npy": npy.Bytes()}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		code, _, stderr := runCLI(t, nil, "info", path)
+		runtime.ReadMemStats(&after)
+		if code != exitOK {
+			t.Fatalf("%s: exit %d: %s", name, code, stderr)
+		}
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > payload+payload/16 {
+			t.Errorf("%s: allocated %d bytes for %d bytes of data", name, alloc, payload)
+		}
+	}
+}
+
+// TestVerify тестирует сравнение последовательного и параллельного умножения
+func TestVerify(t *testing.T) {
+	for _, typ := range []string{"int32", "float64", "complex64"} {
+		code, stdout, stderr := runCLI(t, nil, "verify", "-type", typ, "-x", "4", "-p", "3", "-lambda", "1", "-mu", "1")
+		if code != exitOK || !strings.HasPrefix(stdout, "✓") {
+			t.Errorf("%s: exit %d, output %q, stderr %q", typ, code, stdout, stderr)
+		}
+	}
+}
+
+// TestExitCodes тестирует коды возврата при ошибках
+func TestExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		args  []string
+		stdin string
+		code  int
+	}{
+		{nil, "", exitUsage},
+		{[]string{"help"}, "", exitOK},
+		{[]string{"unknown"}, "", exitUsage},
+		{[]string{"multiply", "-h"}, "", exitOK},
+		{[]string{"multiply", "-bogus"}, "", exitUsage},
+		{[]string{"multiply", "-"}, "", exitUsage},
+		{[]string{"multiply", "-", "-"}, "", exitUsage},
+		{[]string{"generate", "-kind", "ones"}, "", exitUsage},
+		{[]string{"generate", "-type", "int"}, "", exitUsage},
+		{[]string{"generate", "-lambda", "4294967296"}, "", exitUsage},
+		{[]string{"convert", "-to", "xml", "-"}, "[1]", exitUsage},
+		{[]string{"info", filepath.Join(dir, "missing")}, "", exitFailure},
+		{[]string{"info", "-"}, "[[1,2],[3]]", exitFailure},
+		{[]string{"multiply", "-lambda", "3", "-", filepath.Join(dir, "missing")}, "[1]", exitFailure},
+	}
+	for _, tt := range tests {
+		if code, _, stderr := runCLI(t, []byte(tt.stdin), tt.args...); code != tt.code {
+			t.Errorf("%q: expected exit %d, got %d (%s)", tt.args, tt.code, code, stderr)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"matrix/server"
+)
+
+// shutdownTimeout — время на завершение выполняющихся запросов при остановке
+const shutdownTimeout = 10 * time.Second
+
+func serveCommand(args []string, e *env) error {
+	fs := newFlagSet("serve", "")
+	addr := fs.String("addr", ":8080", "listen address")
+	var cfg server.Config
+	fs.Int64Var(&cfg.MaxBodyBytes, "max-body", server.DefaultMaxBodyBytes, "maximum request body size in bytes")
+	fs.IntVar(&cfg.MaxResultElements, "max-result", server.DefaultMaxResultElements, "maximum number of result elements")
+	fs.DurationVar(&cfg.Timeout, "timeout", server.DefaultTimeout, "per-request timeout")
+	fs.IntVar(&cfg.Workers, "workers", 0, "number of worker goroutines (default: number of CPUs)")
+	if err := parseFlags(fs, args, e); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected arguments %q", errUsage, fs.Args())
+	}
+
+	srv := server.New(cfg)
+	defer srv.Close()
+	httpServer := &http.Server{Addr: *addr, Handler: srv, ReadHeaderTimeout: 10 * time.Second}
+
+	// SIGTERM — обычный сигнал остановки от менеджеров служб и контейнеров
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errs := make(chan error, 1)
+	go func() { errs <- httpServer.ListenAndServe() }()
+	fmt.Fprintf(e.stderr, "mmmatrix: listening on %s\n", *addr)
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package mdm
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// At возвращает элемент с многомерным индексом idx.
+// Паникует с ErrIndexOutOfRange при неверном числе индексов или выходе за границы
+func (m *Matrix[T]) At(idx ...int) T {
+	offset, err := m.Offset(idx...)
+	if err != nil {
+		panic(err)
+	}
+	return m.Data[offset]
+}
+
+// Set записывает v в элемент с многомерным индексом idx.
+// Паникует с ErrIndexOutOfRange при неверном числе индексов или выходе за границы
+func (m *Matrix[T]) Set(v T, idx ...int) {
+	offset, err := m.Offset(idx...)
+	if err != nil {
+		panic(err)
+	}
+	m.Data[offset] = v
+}
+
+// Offset переводит многомерный индекс в смещение в Data с проверкой границ
+func (m *Matrix[T]) Offset(idx ...int) (int, error) {
+	if len(idx) != int(m.P) {
+		return 0, fmt.Errorf("%w: got %d indices for order %d", ErrIndexOutOfRange, len(idx), m.P)
+	}
+	var offset int
+	for axis, i := range idx {
+		n := m.dim(axis)
+		if i < 0 || i >= n {
+			return 0, fmt.Errorf("%w: index %d on axis %d of length %d", ErrIndexOutOfRange, i, axis, n)
+		}
+		offset = offset*n + i
+	}
+	return offset, nil
+}
+
+// All перебирает все элементы в порядке хранения вместе с их многомерными индексами.
+// Срез индекса переиспользуется между итерациями; скопируйте его, если он нужен после шага
+func (m *Matrix[T]) All() iter.Seq2[[]int, T] {
+	shape := m.shape()
+	return func(yield func([]int, T) bool) {
+		index := make([]int, len(shape))
+		for _, v := range m.Data {
+			if !yield(index, v) {
+				return
+			}
+			incrementIndexVector(index, shape)
+		}
+	}
+}
+
+// Axis перебирает элементы вдоль оси k, проходящей через индекс at;
+// компонента at[k] игнорируется, отсутствующий at означает нулевой индекс.
+// Паникует с ErrInvalidAxis или ErrIndexOutOfRange при неверных аргументах
+func (m *Matrix[T]) Axis(k int, at ...int) iter.Seq2[[]int, T] {
+	return m.Slice(m.fixedExcept(k, at))
+}
+
+// Slice перебирает элементы, у которых оси из fixed имеют заданные индексы,
+// в порядке хранения. Срез индекса переиспользуется между итерациями.
+// Паникует с ErrInvalidAxis или ErrIndexOutOfRange при неверных аргументах
+func (m *Matrix[T]) Slice(fixed map[int]int) iter.Seq2[[]int, T] {
+	fixed = maps.Clone(fixed)
+	shape := m.shape()
+	for axis, i := range fixed {
+		if axis < 0 || axis >= len(shape) {
+			panic(fmt.Errorf("%w: axis %d for order %d", ErrInvalidAxis, axis, len(shape)))
+		}
+		if i < 0 || i >= shape[axis] {
+			panic(fmt.Errorf("%w: index %d on axis %d of length %d", ErrIndexOutOfRange, i, axis, shape[axis]))
+		}
+	}
+
+	// Одометр перебирает только свободные оси; смещение в Data
+	// пересчитывается по страйдам
+	var free, freeShape []int
+	for axis, n := range shape {
+		if _, ok := fixed[axis]; !ok {
+			free = append(free, axis)
+			freeShape = append(freeShape, n)
+		}
+	}
+	strides := shapeStrides(shape)
+	base := 0
+	for axis, i := range fixed {
+		base += i * strides[axis]
+	}
+	count, _ := shapeSize(freeShape)
+
+	return func(yield func([]int, T) bool) {
+		index := make([]int, len(shape))
+		for axis, i := range fixed {
+			index[axis] = i
+		}
+		freeIndex := make([]int, len(free))
+		for step := 0; step < count; step++ {
+			offset := base
+			for _, axis := range free {
+				offset += index[axis] * strides[axis]
+			}
+			if !yield(index, m.Data[offset]) {
+				return
+			}
+			incrementIndexVector(freeIndex, freeShape)
+			for i, axis := range free {
+				index[axis] = freeIndex[i]
+			}
+		}
+	}
+}
+
+// fixedExcept строит набор фиксированных осей для Axis
+func (m *Matrix[T]) fixedExcept(k int, at []int) map[int]int {
+	if k < 0 || k >= int(m.P) {
+		panic(fmt.Errorf("%w: axis %d for order %d", ErrInvalidAxis, k, m.P))
+	}
+	if len(at) != 0 && len(at) != int(m.P) {
+		panic(fmt.Errorf("%w: got %d indices for order %d", ErrIndexOutOfRange, len(at), m.P))
+	}
+	fixed := make(map[int]int, m.P)
+	for axis := 0; axis < int(m.P); axis++ {
+		if axis == k {
+			continue
+		}
+		if len(at) != 0 {
+			fixed[axis] = at[axis]
+		} else {
+			fixed[axis] = 0
+		}
+	}
+	return fixed
+}
+
+// dim возвращает длину оси axis без выделения памяти
+func (m *Matrix[T]) dim(axis int) int {
+	if m.dims != nil {
+		return m.dims[axis]
+	}
+	return int(m.X)
+}
+
+// shapeStrides возвращает страйды осей для хранения в порядке C
+func shapeStrides(shape []int) []int {
+	strides := slices.Clone(shape)
+	stride := 1
+	for axis := len(shape) - 1; axis >= 0; axis-- {
+		strides[axis] = stride
+		stride *= shape[axis]
+	}
+	return strides
+}
@@ -0,0 +1,160 @@
+package mdm
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// newSequence создаёт матрицу формы shape с элементами 0, 1, 2, ... в порядке хранения
+func newSequence(t *testing.T, shape ...int) *Matrix[int64] {
+	t.Helper()
+	m, err := NewShaped[int64](shape...)
+	if err != nil {
+		t.Fatalf("NewShaped: %v", err)
+	}
+	for i := range m.Data {
+		m.Data[i] = int64(i)
+	}
+	return m
+}
+
+// expectPanic проверяет, что f паникует ошибкой target
+func expectPanic(t *testing.T, target error, f func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		err, _ := recover().(error)
+		if !errors.Is(err, target) {
+			t.Errorf("Expected panic with %v, got %v", target, err)
+		}
+	}()
+	f()
+}
+
+// TestAtSet тестирует доступ к элементам по многомерному индексу
+func TestAtSet(t *testing.T) {
+	m := newSequence(t, 2, 3, 4)
+
+	if v := m.At(1, 2, 3); v != 23 {
+		t.Errorf("At(1, 2, 3): got %d, expected 23", v)
+	}
+	if v := m.At(0, 1, 0); v != 4 {
+		t.Errorf("At(0, 1, 0): got %d, expected 4", v)
+	}
+
+	m.Set(-1, 1, 0, 2)
+	if m.Data[14] != -1 {
+		t.Errorf("Set(-1, 1, 0, 2): Data[14] = %d, expected -1", m.Data[14])
+	}
+
+	cubic := CreateMatrix[uint32](3, 2)
+	cubic.Set(7, 2, 1)
+	if cubic.Data[7] != 7 || cubic.At(2, 1) != 7 {
+		t.Errorf("Set/At on cubic matrix: Data = %v", cubic.Data)
+	}
+
+	if _, err := m.Offset(1, 3, 0); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Offset out of bounds: expected ErrIndexOutOfRange, got %v", err)
+	}
+	if _, err := m.Offset(1, 2); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Offset with wrong arity: expected ErrIndexOutOfRange, got %v", err)
+	}
+	expectPanic(t, ErrIndexOutOfRange, func() { m.At(-1, 0, 0) })
+	expectPanic(t, ErrIndexOutOfRange, func() { m.Set(0, 0, 0, 4) })
+}
+
+// TestIterators тестирует обход элементов через All, Axis и Slice
+func TestIterators(t *testing.T) {
+	m := newSequence(t, 2, 3, 4)
+
+	t.Run("All", func(t *testing.T) {
+		count := 0
+		for index, v := range m.All() {
+			if m.At(index...) != v || v != int64(count) {
+				t.Fatalf("All: index %v yielded %d, expected %d", index, v, count)
+			}
+			count++
+		}
+		if count != len(m.Data) {
+			t.Errorf("All: visited %d elements, expected %d", count, len(m.Data))
+		}
+	})
+
+	t.Run("Axis", func(t *testing.T) {
+		var got [][]int
+		var values []int64
+		for index, v := range m.Axis(1, 1, 0, 2) {
+			got = append(got, slices.Clone(index))
+			values = append(values, v)
+		}
+		expected := [][]int{{1, 0, 2}, {1, 1, 2}, {1, 2, 2}}
+		if !slices.EqualFunc(got, expected, slices.Equal) {
+			t.Errorf("Axis indices: got %v, expected %v", got, expected)
+		}
+		if !slices.Equal(values, []int64{14, 18, 22}) {
+			t.Errorf("Axis values: got %v, expected [14 18 22]", values)
+		}
+
+		values = values[:0]
+		for _, v := range m.Axis(2) {
+			values = append(values, v)
+		}
+		if !slices.Equal(values, []int64{0, 1, 2, 3}) {
+			t.Errorf("Axis without origin: got %v, expected [0 1 2 3]", values)
+		}
+	})
+
+	t.Run("Slice", func(t *testing.T) {
+		var values []int64
+		for index, v := range m.Slice(map[int]int{1: 2}) {
+			if index[1] != 2 || m.At(index...) != v {
+				t.Fatalf("Slice: index %v yielded %d", index, v)
+			}
+			values = append(values, v)
+		}
+		if !slices.Equal(values, []int64{8, 9, 10, 11, 20, 21, 22, 23}) {
+			t.Errorf("Slice values: got %v", values)
+		}
+
+		count := 0
+		for range m.Slice(map[int]int{0: 1, 1: 1, 2: 1}) {
+			count++
+		}
+		if count != 1 {
+			t.Errorf("Slice with all axes fixed: visited %d elements, expected 1", count)
+		}
+	})
+
+	t.Run("early break", func(t *testing.T) {
+		count := 0
+		for range m.All() {
+			count++
+			if count == 5 {
+				break
+			}
+		}
+		if count != 5 {
+			t.Errorf("All after break: visited %d elements, expected 5", count)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		expectPanic(t, ErrInvalidAxis, func() { m.Axis(3) })
+		expectPanic(t, ErrIndexOutOfRange, func() { m.Axis(0, 0, 0) })
+		expectPanic(t, ErrInvalidAxis, func() { m.Slice(map[int]int{5: 0}) })
+		expectPanic(t, ErrIndexOutOfRange, func() { m.Slice(map[int]int{2: 4}) })
+	})
+
+	t.Run("allocations", func(t *testing.T) {
+		seq := m.All()
+		allocs := testing.AllocsPerRun(100, func() {
+			for range seq {
+			}
+		})
+		// Единственное выделение — буфер индекса на весь обход
+		if allocs > 1 {
+			t.Errorf("All: %v allocations per run, expected at most 1", allocs)
+		}
+	})
+}
@@ -0,0 +1,353 @@
+package mdm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"unsafe"
+)
+
+// Ошибки чтения сохранённых матриц
+var (
+	ErrInvalidFormat = errors.New("mdm: invalid encoding")
+	ErrChecksum      = errors.New("mdm: checksum mismatch")
+)
+
+// Двоичный формат матрицы (версия 1). Поля заголовка записываются в
+// порядке little-endian:
+//
+//	magic   [4]byte  "MDMX"
+//	version uint16   1
+//	kind    uint8    тип элемента, см. binaryKinds
+//	flags   uint8    binaryBigEndian, binaryShaped
+//	P       uint32   порядок матрицы
+//	X       uint32   длина осей кубической матрицы, либо
+//	shape   [P]uint32 длины осей при флаге binaryShaped
+//	data    ...      элементы в порядке Data с порядком байтов из flags
+//	crc     uint32   CRC-32C всех предыдущих байтов, little-endian
+//
+// Данные пишутся в порядке байтов машины без преобразования; при чтении
+// на машине с другим порядком байты переставляются на месте
+const (
+	binaryMagic   = "MDMX"
+	binaryVersion = 1
+
+	binaryBigEndian = 1 << 0
+	binaryShaped    = 1 << 1
+
+	binaryHeaderSize = 12
+
+	// readChunk — наибольшая часть данных в байтах, читаемая за один вызов
+	readChunk = 1 << 20
+	// trustShift: размер данных из заголовка считается подтверждённым, когда
+	// прочитана его 1/2^trustShift часть
+	trustShift = 3
+)
+
+// binaryKinds — коды типов элементов в заголовке
+var binaryKinds = [...]reflect.Kind{
+	1: reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+	reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+	reflect.Float32, reflect.Float64,
+	reflect.Complex64, reflect.Complex128,
+}
+
+var (
+	crcTable        = crc32.MakeTable(crc32.Castagnoli)
+	nativeBigEndian = binary.NativeEndian.Uint16([]byte{0, 1}) == 1
+)
+
+// Header — заголовок матрицы в двоичном формате. Заголовок получают только
+// из ReadHeader: он хранит контрольную сумму прочитанных байтов, и
+// созданный вручную Header отвергается ReadData
+type Header struct {
+	Version int
+	// Kind — тип элемента: reflect.Int8 ... reflect.Complex128
+	Kind  reflect.Kind
+	Shape []int
+	// ByteOrder — порядок байтов данных
+	ByteOrder binary.ByteOrder
+
+	// Длина осей кубической матрицы; сохраняется и при P == 0
+	cubic bool
+	x     uint32
+
+	// Контрольная сумма прочитанных байтов заголовка
+	crc hash.Hash32
+}
+
+// WriteTo записывает матрицу в w в двоичном формате и реализует io.WriterTo.
+// Данные передаются в w без промежуточной копии
+func (m *Matrix[T]) WriteTo(w io.Writer) (int64, error) {
+	if err := m.validate(); err != nil {
+		return 0, err
+	}
+	code := slices.Index(binaryKinds[:], reflect.TypeFor[T]().Kind())
+
+	shape := m.shape()
+	header := make([]byte, binaryHeaderSize, binaryHeaderSize+4*len(shape))
+	copy(header, binaryMagic)
+	binary.LittleEndian.PutUint16(header[4:], binaryVersion)
+	header[6] = byte(code)
+	if nativeBigEndian {
+		header[7] |= binaryBigEndian
+	}
+	binary.LittleEndian.PutUint32(header[8:], m.P)
+	if m.IsCubic() {
+		header = binary.LittleEndian.AppendUint32(header, m.X)
+	} else {
+		header[7] |= binaryShaped
+		for _, n := range shape {
+			header = binary.LittleEndian.AppendUint32(header, uint32(n))
+		}
+	}
+
+	crc := crc32.New(crcTable)
+	cw := &countingWriter{w: io.MultiWriter(w, crc)}
+	if _, err := cw.Write(header); err != nil {
+		return cw.n, err
+	}
+	if _, err := cw.Write(dataBytes(m.Data)); err != nil {
+		return cw.n, err
+	}
+	n, err := w.Write(binary.LittleEndian.AppendUint32(nil, crc.Sum32()))
+	return cw.n + int64(n), err
+}
+
+// ReadMatrix читает матрицу, записанную WriteTo. Тип элемента в заголовке
+// должен совпадать с T, иначе возвращается ErrUnsupportedType. Данные
+// читаются сразу в Data; если длина r неизвестна, память под них выделяется
+// лишь после получения части данных, а не по одному размеру из заголовка.
+// Если r пуст, возвращается io.EOF, что позволяет читать подряд записанные
+// матрицы
+func ReadMatrix[T Number](r io.Reader) (*Matrix[T], error) {
+	h, err := ReadHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return ReadData[T](r, h)
+}
+
+// ReadHeader читает заголовок матрицы. Данные читаются затем ReadData
+// с тем же заголовком, что позволяет выбрать T по h.Kind.
+// Ошибки формата сопоставляются с ErrInvalidFormat
+func ReadHeader(r io.Reader) (*Header, error) {
+	crc := crc32.New(crcTable)
+	tr := io.TeeReader(r, crc)
+
+	fixed := make([]byte, binaryHeaderSize)
+	if _, err := io.ReadFull(tr, fixed); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%w: header: %w", ErrInvalidFormat, err)
+	}
+	if string(fixed[:4]) != binaryMagic {
+		return nil, fmt.Errorf("%w: bad magic %q", ErrInvalidFormat, fixed[:4])
+	}
+	if v := binary.LittleEndian.Uint16(fixed[4:]); v != binaryVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidFormat, v)
+	}
+	code, flags := int(fixed[6]), fixed[7]
+	if code == 0 || code >= len(binaryKinds) {
+		return nil, fmt.Errorf("%w: unknown element type %d", ErrInvalidFormat, code)
+	}
+	if flags&^(binaryBigEndian|binaryShaped) != 0 {
+		return nil, fmt.Errorf("%w: unknown flags %#x", ErrInvalidFormat, flags)
+	}
+
+	h := &Header{Version: binaryVersion, Kind: binaryKinds[code], ByteOrder: binary.LittleEndian, crc: crc}
+	if flags&binaryBigEndian != 0 {
+		h.ByteOrder = binary.BigEndian
+	}
+	p := binary.LittleEndian.Uint32(fixed[8:])
+	shape := make([]int, 0, min(p, maxOrder))
+	if flags&binaryShaped != 0 {
+		if p > maxOrder {
+			return nil, fmt.Errorf("%w: order %d exceeds %d", ErrInvalidShape, p, maxOrder)
+		}
+		lengths := make([]byte, 4)
+		for range p {
+			if _, err := io.ReadFull(tr, lengths); err != nil {
+				return nil, fmt.Errorf("%w: shape: %w", ErrInvalidFormat, noEOF(err))
+			}
+			shape = append(shape, int(binary.LittleEndian.Uint32(lengths)))
+		}
+	} else {
+		x := make([]byte, 4)
+		if _, err := io.ReadFull(tr, x); err != nil {
+			return nil, fmt.Errorf("%w: shape: %w", ErrInvalidFormat, noEOF(err))
+		}
+		h.cubic, h.x = true, binary.LittleEndian.Uint32(x)
+		if _, err := checkCubic(h.x, p); err != nil {
+			return nil, err
+		}
+		shape = cubicShape(h.x, p)
+	}
+	if _, err := checkShape(shape); err != nil {
+		return nil, err
+	}
+	h.Shape = shape
+	return h, nil
+}
+
+// ReadData читает данные и контрольную сумму матрицы с заголовком h,
+// прочитанным ReadHeader из того же r. Заголовок, полученный не из
+// ReadHeader, даёт ErrInvalidFormat
+func ReadData[T Number](r io.Reader, h *Header) (*Matrix[T], error) {
+	if h == nil || h.crc == nil {
+		return nil, fmt.Errorf("%w: header was not read by ReadHeader", ErrInvalidFormat)
+	}
+	if kind := reflect.TypeFor[T]().Kind(); kind != h.Kind {
+		return nil, fmt.Errorf("%w: encoded %v, want %v", ErrUnsupportedType, h.Kind, kind)
+	}
+	size, err := checkShape(h.Shape)
+	if err != nil {
+		return nil, err
+	}
+	elem := int(unsafe.Sizeof(T(0)))
+	if size > math.MaxInt/elem {
+		return nil, fmt.Errorf("%w: shape %v", ErrSizeOverflow, h.Shape)
+	}
+	data, err := readElements[T](io.TeeReader(r, h.crc), size, hasBytes(r, int64(size*elem)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: data: %w", ErrInvalidFormat, err)
+	}
+	m := fromShape(h.Shape, data)
+	if h.cubic {
+		m.X = h.x
+	}
+
+	raw := dataBytes(m.Data)
+	sum := make([]byte, 4)
+	if _, err := io.ReadFull(r, sum); err != nil {
+		return nil, fmt.Errorf("%w: checksum: %w", ErrInvalidFormat, noEOF(err))
+	}
+	if binary.LittleEndian.Uint32(sum) != h.crc.Sum32() {
+		return nil, ErrChecksum
+	}
+	if (h.ByteOrder == binary.BigEndian) != nativeBigEndian {
+		swapBytes(raw, elementWordSize(h.Kind, elem))
+	}
+	return m, nil
+}
+
+// readElements читает size элементов T из r прямо в их срез, см. loadElements.
+// Усечённый поток даёт io.ErrUnexpectedEOF
+func readElements[T Number](r io.Reader, size int, known bool) ([]T, error) {
+	return loadElements(size, known, func(dst []T) error {
+		_, err := io.ReadFull(r, dataBytes(dst))
+		return noEOF(err)
+	})
+}
+
+// loadElements создаёт срез из size элементов и заполняет его по порядку
+// вызовами fill, каждый из которых заполняет dst не больше readChunk байтов
+// целиком. Если known, длина входа подтверждает размер и срез выделяется
+// сразу. Иначе первая 1/2^trustShift часть данных читается в отдельные
+// блоки; после неё размер считается подтверждённым, срез выделяется один
+// раз, блоки копируются в него, а остальное читается на место. Поэтому
+// повреждённый заголовок не заставляет выделить больше 2^trustShift
+// объёмов полученных данных, а поток неизвестной длины не требует
+// перекопирования прочитанного при росте среза
+func loadElements[T Number](size int, known bool, fill func(dst []T) error) ([]T, error) {
+	chunk := max(readChunk/int(unsafe.Sizeof(T(0))), 1)
+	var blocks [][]T
+	if !known {
+		for read := 0; read < size>>trustShift; {
+			block := make([]T, min(chunk, size-read))
+			if err := fill(block); err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, block)
+			read += len(block)
+		}
+	}
+	data := make([]T, size)
+	offset := 0
+	for _, block := range blocks {
+		offset += copy(data[offset:], block)
+	}
+	for offset < size {
+		n := min(chunk, size-offset)
+		if err := fill(data[offset : offset+n]); err != nil {
+			return nil, err
+		}
+		offset += n
+	}
+	return data, nil
+}
+
+// hasBytes сообщает, известно ли, что в r осталось не меньше n байтов.
+// Длина известна у bytes.Reader, strings.Reader, bytes.Buffer и входов
+// с произвольным доступом, например обычных файлов
+func hasBytes(r io.Reader, n int64) bool {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len()) >= n
+	case io.Seeker:
+		cur, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return false
+		}
+		end, err := r.Seek(0, io.SeekEnd)
+		if _, serr := r.Seek(cur, io.SeekStart); err != nil || serr != nil {
+			return false
+		}
+		return end-cur >= n
+	}
+	return false
+}
+
+// dataBytes возвращает байты элементов data без копирования
+func dataBytes[T Number](data []T) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&data[0])), len(data)*int(unsafe.Sizeof(data[0])))
+}
+
+// elementWordSize возвращает размер слова, в котором переставляются байты:
+// комплексные числа состоят из двух независимых вещественных частей
+func elementWordSize(kind reflect.Kind, size int) int {
+	if kind == reflect.Complex64 || kind == reflect.Complex128 {
+		return size / 2
+	}
+	return size
+}
+
+// swapBytes меняет порядок байтов в каждом слове размера word
+func swapBytes(raw []byte, word int) {
+	if word <= 1 {
+		return
+	}
+	for i := 0; i+word <= len(raw); i += word {
+		slices.Reverse(raw[i : i+word])
+	}
+}
+
+// noEOF заменяет io.EOF на io.ErrUnexpectedEOF для усечённого потока
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// countingWriter подсчитывает записанные байты
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,234 @@
+package mdm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"math"
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// roundTrip записывает матрицу и читает её обратно
+func roundTrip[T Number](t *testing.T, m *Matrix[T]) *Matrix[T] {
+	t.Helper()
+	var buf bytes.Buffer
+	n, err := m.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+	}
+	got, err := ReadMatrix[T](&buf)
+	if err != nil {
+		t.Fatalf("ReadMatrix: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("%d bytes left unread", buf.Len())
+	}
+	return got
+}
+
+// TestBinaryRoundTrip тестирует запись и чтение матриц разных типов и форм
+func TestBinaryRoundTrip(t *testing.T) {
+	m := newSequence(t, 2, 3, 4)
+	compareMatrices(t, m, roundTrip(t, m))
+
+	cubic := CreateMatrix[int8](3, 3)
+	for i := range cubic.Data {
+		cubic.Data[i] = int8(i - 13)
+	}
+	compareMatrices(t, cubic, roundTrip(t, cubic))
+
+	c := CreateMatrix[complex64](2, 2)
+	copy(c.Data, []complex64{1 + 2i, -3i, 4, 5.5 - 6i})
+	compareMatrices(t, c, roundTrip(t, c))
+
+	f := CreateMatrix[float64](3, 0)
+	f.Data[0] = 2.5
+	compareMatrices(t, f, roundTrip(t, f))
+
+	empty, _ := NewShaped[uint16](2, 0, 3)
+	if got := roundTrip(t, empty); !slices.Equal(got.Shape(), []int{2, 0, 3}) || len(got.Data) != 0 {
+		t.Errorf("Empty matrix: got shape %v, data %v", got.Shape(), got.Data)
+	}
+}
+
+// TestBinaryStream тестирует чтение подряд записанных матриц
+func TestBinaryStream(t *testing.T) {
+	a, b := newSequence(t, 2, 2), newSequence(t, 3)
+	var buf bytes.Buffer
+	a.WriteTo(&buf)
+	b.WriteTo(&buf)
+
+	for _, expected := range []*Matrix[int64]{a, b} {
+		got, err := ReadMatrix[int64](&buf)
+		if err != nil {
+			t.Fatalf("ReadMatrix: %v", err)
+		}
+		compareMatrices(t, expected, got)
+	}
+	if _, err := ReadMatrix[int64](&buf); err != io.EOF {
+		t.Errorf("End of stream: expected io.EOF, got %v", err)
+	}
+}
+
+// TestBinaryHeader тестирует выбор типа по заголовку
+func TestBinaryHeader(t *testing.T) {
+	m := CreateMatrix[float32](2, 3)
+	var buf bytes.Buffer
+	m.WriteTo(&buf)
+
+	h, err := ReadHeader(&buf)
+	if err != nil {
+		t.Fatalf("ReadHeader: %v", err)
+	}
+	if h.Kind != reflect.Float32 || !slices.Equal(h.Shape, []int{2, 2, 2}) || h.ByteOrder == nil {
+		t.Errorf("Unexpected header %+v", h)
+	}
+	got, err := ReadData[float32](&buf, h)
+	if err != nil {
+		t.Fatalf("ReadData: %v", err)
+	}
+	compareMatrices(t, m, got)
+}
+
+// TestBinaryByteOrder тестирует чтение данных с другим порядком байтов
+func TestBinaryByteOrder(t *testing.T) {
+	m := CreateMatrix[complex128](2, 1)
+	copy(m.Data, []complex128{1 - 2i, 3.25 + 4i})
+	var buf bytes.Buffer
+	m.WriteTo(&buf)
+
+	// Переписываем данные в противоположном порядке байтов
+	raw := buf.Bytes()
+	raw[7] ^= binaryBigEndian
+	swapBytes(raw[binaryHeaderSize+4:len(raw)-4], 8)
+	binary.LittleEndian.PutUint32(raw[len(raw)-4:], crc32.Checksum(raw[:len(raw)-4], crcTable))
+
+	got, err := ReadMatrix[complex128](&buf)
+	if err != nil {
+		t.Fatalf("ReadMatrix: %v", err)
+	}
+	compareMatrices(t, m, got)
+}
+
+// TestBinaryErrors тестирует обнаружение повреждённых данных
+func TestBinaryErrors(t *testing.T) {
+	var buf bytes.Buffer
+	newSequence(t, 3, 3).WriteTo(&buf)
+	encoded := buf.Bytes()
+
+	corrupt := func(offset int) []byte {
+		b := slices.Clone(encoded)
+		b[offset] ^= 0x10
+		return b
+	}
+	tests := []struct {
+		name   string
+		data   []byte
+		target error
+	}{
+		{"magic", corrupt(0), ErrInvalidFormat},
+		{"version", corrupt(4), ErrInvalidFormat},
+		{"kind", corrupt(6), ErrInvalidFormat},
+		{"flags", corrupt(7), ErrInvalidFormat},
+		{"data", corrupt(len(encoded) - 10), ErrChecksum},
+		{"checksum", corrupt(len(encoded) - 1), ErrChecksum},
+		{"truncated header", encoded[:5], ErrInvalidFormat},
+		{"truncated data", encoded[:len(encoded)-12], io.ErrUnexpectedEOF},
+		{"truncated checksum", encoded[:len(encoded)-2], ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		if _, err := ReadMatrix[int64](bytes.NewReader(tt.data)); !errors.Is(err, tt.target) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.target, err)
+		}
+	}
+
+	// Размер из заголовка не должен определять выделяемую память
+	header := func(p, x uint32, data int) []byte {
+		b := append([]byte(binaryMagic), 1, 0, 5, 0)
+		b = binary.LittleEndian.AppendUint32(b, p)
+		b = binary.LittleEndian.AppendUint32(b, x)
+		return append(b, make([]byte, data)...)
+	}
+	shaped := func(p uint32, lengths ...uint32) []byte {
+		b := append([]byte(binaryMagic), 1, 0, 5, binaryShaped)
+		b = binary.LittleEndian.AppendUint32(b, p)
+		for _, n := range lengths {
+			b = binary.LittleEndian.AppendUint32(b, n)
+		}
+		return b
+	}
+	crafted := []struct {
+		name   string
+		data   []byte
+		target error
+	}{
+		{"huge declared size", header(4, 1<<15, 4), io.ErrUnexpectedEOF},
+		{"size overflow", header(4, 1<<31, 4), ErrSizeOverflow},
+		{"huge order", header(4e8, 1, 0), ErrInvalidShape},
+		{"huge empty order", header(math.MaxUint32, 0, 0), ErrInvalidShape},
+		{"huge shaped order", shaped(4e8, 1, 1), ErrInvalidShape},
+	}
+	for _, tt := range crafted {
+		if _, err := ReadMatrix[uint8](bytes.NewReader(tt.data)); !errors.Is(err, tt.target) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.target, err)
+		}
+	}
+
+	if _, err := ReadData[int64](bytes.NewReader(encoded), &Header{Kind: reflect.Int64, Shape: []int{3, 3}}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Handmade header: expected ErrInvalidFormat, got %v", err)
+	}
+	if _, err := ReadMatrix[float64](bytes.NewReader(encoded)); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Type mismatch: expected ErrUnsupportedType, got %v", err)
+	}
+	if _, err := (&Matrix[int64]{X: 2, P: 2}).WriteTo(io.Discard); !errors.Is(err, ErrDataLength) {
+		t.Errorf("Invalid matrix: expected ErrDataLength, got %v", err)
+	}
+}
+
+// allocated возвращает число байтов, выделенных при вызове f
+func allocated(f func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	f()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+// TestBinaryReadMemory проверяет, что данные читаются без промежуточных
+// копий: при известной длине входа память выделяется один раз, а поток
+// неизвестной длины копирует лишь часть, прочитанную до выделения среза
+func TestBinaryReadMemory(t *testing.T) {
+	m, _ := NewShaped[float64](128, 1<<16)
+	var buf bytes.Buffer
+	m.WriteTo(&buf)
+	payload := uint64(len(m.Data)) * 8
+
+	tests := []struct {
+		name  string
+		r     io.Reader
+		limit uint64
+	}{
+		{"known length", bytes.NewReader(buf.Bytes()), payload + payload/16},
+		{"stream", struct{ io.Reader }{bytes.NewReader(buf.Bytes())}, payload + payload>>trustShift + 2*readChunk},
+	}
+	for _, tt := range tests {
+		var got *Matrix[float64]
+		var err error
+		alloc := allocated(func() { got, err = ReadMatrix[float64](tt.r) })
+		if err != nil {
+			t.Fatalf("%s: ReadMatrix: %v", tt.name, err)
+		}
+		if alloc > tt.limit {
+			t.Errorf("%s: allocated %d bytes for %d bytes of data", tt.name, alloc, payload)
+		}
+		compareMatrices(t, m, got)
+	}
+}
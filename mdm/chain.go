@@ -0,0 +1,155 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// ErrNotAssociative возвращается, если произведения цепочки нельзя
+// перегруппировать без изменения результата
+var ErrNotAssociative = errors.New("mdm: chain is not associative")
+
+// ChainStep задаёт параметры (λ,μ)-умножения между соседними операндами цепочки
+type ChainStep struct {
+	Lambda, Mu uint32
+}
+
+// Chain — цепочка (λ,μ)-произведений A0 ∘ A1 ∘ ... ∘ An с собственными λ и μ
+// на каждом шаге. Значением цепочки считается вычисление слева направо;
+// Chain выбирает расстановку скобок с наименьшим числом умножений
+type Chain[T Number] struct {
+	operands []*Matrix[T]
+	steps    []ChainStep
+
+	// split[i][j] — шаг, по которому делится подцепочка i..j
+	split [][]int
+	cost  float64
+}
+
+// NewChain проверяет операнды и шаги цепочки и выбирает порядок умножений
+// динамическим программированием по размерам промежуточных результатов.
+//
+// Перегруппировка допустима, если шаги не делят оси общего операнда:
+// для каждого внутреннего операнда Ai должно выполняться
+// λ(i-1) + μ(i-1) + λ(i) + μ(i) <= P(Ai), иначе возвращается ErrNotAssociative.
+// Ошибки форм сопоставляются с ErrDimensionMismatch и ErrInvalidLambdaMu
+func NewChain[T Number](operands []*Matrix[T], steps []ChainStep) (*Chain[T], error) {
+	if len(operands) == 0 || len(steps) != len(operands)-1 {
+		return nil, fmt.Errorf("%w: %d steps for %d operands", ErrInvalidLambdaMu, len(steps), len(operands))
+	}
+	n := len(operands)
+	for i, op := range operands {
+		if err := op.validate(); err != nil {
+			return nil, fmt.Errorf("operand %d: %w", i, err)
+		}
+	}
+	for i := 1; i < n-1; i++ {
+		left, right := steps[i-1], steps[i]
+		used := uint64(left.Lambda) + uint64(left.Mu) + uint64(right.Lambda) + uint64(right.Mu)
+		if used > uint64(operands[i].P) {
+			return nil, fmt.Errorf("%w: operand %d of order %d is shared by steps %v and %v",
+				ErrNotAssociative, i, operands[i].P, left, right)
+		}
+	}
+
+	// shapes[i][j] — форма произведения подцепочки i..j; при выполненном
+	// условии ассоциативности она не зависит от расстановки скобок
+	shapes := make([][][]int, n)
+	for i := range shapes {
+		shapes[i] = make([][]int, n)
+		shapes[i][i] = operands[i].shape()
+		for j := i + 1; j < n; j++ {
+			shape, _, err := resultShape(shapes[i][j-1], operands[j].shape(), steps[j-1].Lambda, steps[j-1].Mu)
+			if err != nil {
+				return nil, fmt.Errorf("step %d: %w", j-1, err)
+			}
+			shapes[i][j] = shape
+		}
+	}
+
+	cost := make([][]float64, n)
+	split := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]float64, n)
+		split[i] = make([]int, n)
+	}
+	for length := 2; length <= n; length++ {
+		for i := 0; i+length <= n; i++ {
+			j := i + length - 1
+			cost[i][j] = math.Inf(1)
+			for k := i; k < j; k++ {
+				c := cost[i][k] + cost[k+1][j] + stepFlops(shapes[i][k], shapes[i][j], steps[k].Mu)
+				if c < cost[i][j] {
+					cost[i][j], split[i][j] = c, k
+				}
+			}
+		}
+	}
+	return &Chain[T]{operands: operands, steps: steps, split: split, cost: cost[0][n-1]}, nil
+}
+
+// stepFlops оценивает число умножений при свёртке левого сомножителя формы
+// lhs в результат формы result: каждая ячейка суммирует по кэлиевым осям
+func stepFlops(lhs, result []int, mu uint32) float64 {
+	flops := 1.0
+	for _, n := range result {
+		flops *= float64(n)
+	}
+	for _, n := range lhs[len(lhs)-int(mu):] {
+		flops *= float64(n)
+	}
+	return flops
+}
+
+// Cost возвращает число умножений при выбранной расстановке скобок
+func (c *Chain[T]) Cost() float64 {
+	return c.cost
+}
+
+// Order возвращает выбранную расстановку скобок, например "((A0 A1) A2)"
+func (c *Chain[T]) Order() string {
+	return c.order(0, len(c.operands)-1)
+}
+
+func (c *Chain[T]) order(i, j int) string {
+	if i == j {
+		return "A" + strconv.Itoa(i)
+	}
+	k := c.split[i][j]
+	return "(" + c.order(i, k) + " " + c.order(k+1, j) + ")"
+}
+
+// Execute вычисляет цепочку в выбранном порядке через ParallelMultiplyChecked.
+// Для цепочки из одного операнда возвращается его копия. Ошибка шага
+// возвращается с его номером
+func (c *Chain[T]) Execute() (*Matrix[T], error) {
+	if len(c.operands) == 1 {
+		op := c.operands[0]
+		return fromShape(op.shape(), slices.Clone(op.Data)), nil
+	}
+	return c.execute(0, len(c.operands)-1)
+}
+
+func (c *Chain[T]) execute(i, j int) (*Matrix[T], error) {
+	if i == j {
+		return c.operands[i], nil
+	}
+	k := c.split[i][j]
+	lhs, err := c.execute(i, k)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := c.execute(k+1, j)
+	if err != nil {
+		return nil, err
+	}
+	step := c.steps[k]
+	result, err := lhs.ParallelMultiplyChecked(step.Lambda, step.Mu, rhs)
+	if err != nil {
+		return nil, fmt.Errorf("step %d: %w", k, err)
+	}
+	return result, nil
+}
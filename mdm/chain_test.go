@@ -0,0 +1,103 @@
+package mdm
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestChainOrder тестирует выбор расстановки скобок для обычных матриц
+func TestChainOrder(t *testing.T) {
+	a := filledShaped(t, 5, 10, 100)
+	b := filledShaped(t, 3, 100, 5)
+	c := filledShaped(t, 2, 5, 50)
+	steps := []ChainStep{{0, 1}, {0, 1}}
+
+	chain, err := NewChain([]*Matrix[int64]{a, b, c}, steps)
+	if err != nil {
+		t.Fatalf("NewChain: %v", err)
+	}
+	// (AB)C: 10·100·5 + 10·5·50 = 7500 умножений против 75000 у A(BC)
+	if chain.Order() != "((A0 A1) A2)" || chain.Cost() != 7500 {
+		t.Errorf("Order %s with cost %g, expected ((A0 A1) A2) with cost 7500", chain.Order(), chain.Cost())
+	}
+	got, err := chain.Execute()
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	compareMatrices(t, a.Multiplication(0, 1, b).Multiplication(0, 1, c), got)
+
+	// A(BC): 5·100·10 + 50·5·10 = 7500 умножений против 75000 у (AB)C
+	chain, err = NewChain([]*Matrix[int64]{filledShaped(t, 7, 50, 5), filledShaped(t, 3, 5, 100), filledShaped(t, 2, 100, 10)}, steps)
+	if err != nil {
+		t.Fatalf("NewChain: %v", err)
+	}
+	if chain.Order() != "(A0 (A1 A2))" || chain.Cost() != 7500 {
+		t.Errorf("Order %s with cost %g, expected (A0 (A1 A2)) with cost 7500", chain.Order(), chain.Cost())
+	}
+}
+
+// TestChainMultidimensional тестирует совпадение любой расстановки скобок
+// с вычислением слева направо для цепочки с разными λ и μ
+func TestChainMultidimensional(t *testing.T) {
+	ops := []*Matrix[int64]{
+		filledShaped(t, 5, 2, 2, 2),
+		filledShaped(t, 3, 2, 2, 2, 2),
+		filledShaped(t, 2, 2, 2, 2),
+		filledShaped(t, 7, 2, 2, 2, 2),
+	}
+	steps := []ChainStep{{1, 1}, {0, 2}, {1, 0}}
+
+	chain, err := NewChain(ops, steps)
+	if err != nil {
+		t.Fatalf("NewChain: %v", err)
+	}
+	expected := ops[0]
+	for i, step := range steps {
+		expected = expected.Multiplication(step.Lambda, step.Mu, ops[i+1])
+	}
+	got, err := chain.Execute()
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	compareMatrices(t, expected, got)
+
+	// Правая группировка даёт тот же результат
+	right := ops[0].Multiplication(1, 1, ops[1].Multiplication(0, 2, ops[2].Multiplication(1, 0, ops[3])))
+	compareMatrices(t, expected, right)
+}
+
+// TestChainErrors тестирует проверку цепочки
+func TestChainErrors(t *testing.T) {
+	a := CreateMatrix[int64](2, 2)
+	if _, err := NewChain([]*Matrix[int64]{a, a, a}, []ChainStep{{0, 2}, {0, 1}}); !errors.Is(err, ErrNotAssociative) {
+		t.Errorf("Shared axes: expected ErrNotAssociative, got %v", err)
+	}
+	if _, err := NewChain([]*Matrix[int64]{a, a}, nil); !errors.Is(err, ErrInvalidLambdaMu) {
+		t.Errorf("Missing steps: expected ErrInvalidLambdaMu, got %v", err)
+	}
+	if _, err := NewChain([]*Matrix[int64]{a, CreateMatrix[int64](3, 2)}, []ChainStep{{0, 1}}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Mismatched shapes: expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := NewChain([]*Matrix[int64]{a, nil}, []ChainStep{{0, 1}}); !errors.Is(err, ErrNilMatrix) {
+		t.Errorf("Nil operand: expected ErrNilMatrix, got %v", err)
+	}
+
+	chain, err := NewChain([]*Matrix[int64]{a}, nil)
+	if err != nil {
+		t.Fatalf("NewChain: %v", err)
+	}
+	if r, err := chain.Execute(); err != nil || r == a || chain.Cost() != 0 || chain.Order() != "A0" {
+		t.Errorf("Single operand chain must return a copy with zero cost")
+	}
+
+	// Операнд, испорченный после построения цепочки, даёт ошибку, а не панику
+	b := CreateMatrix[int64](2, 2)
+	chain, err = NewChain([]*Matrix[int64]{a, b}, []ChainStep{{0, 1}})
+	if err != nil {
+		t.Fatalf("NewChain: %v", err)
+	}
+	b.Data = b.Data[:1]
+	if _, err := chain.Execute(); !errors.Is(err, ErrDataLength) {
+		t.Errorf("Corrupted operand: expected ErrDataLength, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package mdm
+
+import "context"
+
+// MultiplyWith — последовательный вариант ParallelMultiplyWith: вычисление
+// идёт в текущей горутине, но проверяет ctx между порциями работы и сообщает
+// о ходе выполнения через opts.Progress. Поля Workers и Pool не используются
+func (m *Matrix[T]) MultiplyWith(ctx context.Context, lambda, mu uint32, other *Matrix[T], opts *Options) (*Matrix[T], error) {
+	return m.multiplyWith(ctx, lambda, mu, other, opts, false)
+}
+
+// ParallelMultiplyContext выполняет параллельное матричное умножение с
+// возможностью отмены. Горутины проверяют ctx между порциями работы; при
+// отмене или истечении срока частичный результат отбрасывается и
+// возвращается ctx.Err(). К моменту возврата все горутины завершены
+func (m *Matrix[T]) ParallelMultiplyContext(ctx context.Context, lambda, mu uint32, other *Matrix[T]) (*Matrix[T], error) {
+	return m.ParallelMultiplyWith(ctx, lambda, mu, other, nil)
+}
+
+// ParallelMultiplyWith — вариант ParallelMultiplyContext с параметрами
+// параллельного выполнения и отчётами о ходе выполнения через opts.Progress;
+// opts == nil означает параметры по умолчанию
+func (m *Matrix[T]) ParallelMultiplyWith(ctx context.Context, lambda, mu uint32, other *Matrix[T], opts *Options) (*Matrix[T], error) {
+	return m.multiplyWith(ctx, lambda, mu, other, opts, true)
+}
+
+// multiplyWith проверяет операнды и выполняет умножение по плану через runWith
+func (m *Matrix[T]) multiplyWith(ctx context.Context, lambda, mu uint32, other *Matrix[T], opts *Options, parallel bool) (*Matrix[T], error) {
+	if _, _, err := validateOperands(m, other, lambda, mu); err != nil {
+		return nil, err
+	}
+	plan, err := NewShapedPlan[T](m.shape(), other.shape(), lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	matrixResult := plan.NewResult()
+	if err := plan.runWith(ctx, m, other, matrixResult, opts, parallel); err != nil {
+		return nil, err
+	}
+	return matrixResult, nil
+}
+
+// ExecuteWith — последовательный вариант ParallelExecuteWith с проверкой ctx
+// и отчётами о ходе выполнения; поля Workers и Pool не используются
+func (p *Plan[T]) ExecuteWith(ctx context.Context, lhs, rhs, out *Matrix[T], opts *Options) error {
+	if err := p.check(lhs, rhs, out); err != nil {
+		return err
+	}
+	return p.runWith(ctx, lhs, rhs, out, opts, false)
+}
+
+// ParallelExecuteContext — вариант ParallelExecute с возможностью отмены.
+// При отмене возвращается ctx.Err(), а содержимое out не определено
+func (p *Plan[T]) ParallelExecuteContext(ctx context.Context, lhs, rhs, out *Matrix[T]) error {
+	return p.ParallelExecuteWith(ctx, lhs, rhs, out, nil)
+}
+
+// ParallelExecuteWith — вариант ParallelExecuteContext с параметрами
+// параллельного выполнения и отчётами о ходе выполнения через opts.Progress;
+// opts == nil означает параметры по умолчанию
+func (p *Plan[T]) ParallelExecuteWith(ctx context.Context, lhs, rhs, out *Matrix[T], opts *Options) error {
+	if err := p.check(lhs, rhs, out); err != nil {
+		return err
+	}
+	return p.runWith(ctx, lhs, rhs, out, opts, true)
+}
+
+// runWith выполняет план выбранной стратегией с проверкой ctx
+func (p *Plan[T]) runWith(ctx context.Context, lhs, rhs, out *Matrix[T], opts *Options, parallel bool) error {
+	switch p.strategy {
+	case StrategyOdometer:
+		return opts.schedule(ctx, cellWorkload(p.size, p.cSize, lhs.odometerRange(p.lambda, p.mu, rhs, out)), parallel)
+	case StrategyGEMM:
+		return opts.schedule(ctx, workload{
+			units:     p.gemmTasks(),
+			cells:     p.size,
+			unitCells: gemmBlockRows * p.mSize,
+			unitCost:  gemmBlockRows * p.cSize * p.mSize,
+			run: func(start, end int) int {
+				p.gemmRange(lhs.Data, rhs.Data, out.Data, start, end)
+				return p.gemmCells(start, end)
+			},
+		}, parallel)
+	default:
+		return opts.schedule(ctx, cellWorkload(p.size, p.cSize, func(start, end int) {
+			p.executeRange(lhs.Data, rhs.Data, out.Data, start, end)
+		}), parallel)
+	}
+}
@@ -0,0 +1,84 @@
+package mdm
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestParallelMultiplyContext тестирует совпадение результата с ParallelMultiplication
+func TestParallelMultiplyContext(t *testing.T) {
+	lhs := CreateMatrix[int64](3, 4)
+	rhs := CreateMatrix[int64](3, 4)
+	for i := range lhs.Data {
+		lhs.Data[i] = int64(i%7) - 3
+		rhs.Data[i] = int64(i%5) - 2
+	}
+
+	result, err := lhs.ParallelMultiplyContext(context.Background(), 1, 2, rhs)
+	if err != nil {
+		t.Fatalf("ParallelMultiplyContext: %v", err)
+	}
+	compareMatrices(t, lhs.ParallelMultiplication(1, 2, rhs), result)
+
+	for _, strategy := range []Strategy{StrategyDirect, StrategyGEMM, StrategyOdometer} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			plan, err := NewPlan[int64](3, 4, 4, 0, 2)
+			if err != nil {
+				t.Fatalf("NewPlan: %v", err)
+			}
+			if err := plan.SetStrategy(strategy); err != nil {
+				t.Fatalf("SetStrategy: %v", err)
+			}
+			out := plan.NewResult()
+			if err := plan.ParallelExecuteContext(context.Background(), lhs, rhs, out); err != nil {
+				t.Fatalf("ParallelExecuteContext: %v", err)
+			}
+			compareMatrices(t, lhs.Multiplication(0, 2, rhs), out)
+		})
+	}
+}
+
+// TestParallelMultiplyContextCancel тестирует отмену умножения и завершение горутин
+func TestParallelMultiplyContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	a := CreateMatrix[uint32](2, 2)
+	if result, err := a.ParallelMultiplyContext(ctx, 0, 1, a); !errors.Is(err, context.Canceled) || result != nil {
+		t.Errorf("Cancelled context: expected context.Canceled and nil result, got %v, %v", result, err)
+	}
+
+	// Полное умножение занимает заметно дольше срока контекста
+	lhs := CreateMatrix[float64](5, 8)
+	rhs := CreateMatrix[float64](5, 8)
+	for _, strategy := range []Strategy{StrategyDirect, StrategyGEMM, StrategyOdometer} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			plan, err := NewPlan[float64](5, 8, 8, 0, 4)
+			if err != nil {
+				t.Fatalf("NewPlan: %v", err)
+			}
+			if err := plan.SetStrategy(strategy); err != nil {
+				t.Fatalf("SetStrategy: %v", err)
+			}
+			out := plan.NewResult()
+
+			goroutines := runtime.NumGoroutine()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err = plan.ParallelExecuteContext(ctx, lhs, rhs, out)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Cancellation took %v", elapsed)
+			}
+			if n := runtime.NumGoroutine(); n > goroutines {
+				t.Errorf("Goroutines leaked: %d before, %d after", goroutines, n)
+			}
+		})
+	}
+}
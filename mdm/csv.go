@@ -0,0 +1,113 @@
+package mdm
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// WriteCSV записывает матрицу в «длинном» формате CSV: строка заголовка
+// i1,…,iP,value и по строке на каждый элемент в порядке Data. Индексы
+// начинаются с 0, значения записываются как в MarshalJSON
+func (m *Matrix[T]) WriteCSV(w io.Writer) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	record := make([]string, m.P+1)
+	for axis := range m.P {
+		record[axis] = "i" + strconv.Itoa(int(axis)+1)
+	}
+	record[m.P] = "value"
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for idx, v := range m.All() {
+		for axis, i := range idx {
+			record[axis] = strconv.Itoa(i)
+		}
+		record[m.P] = formatElement(v)
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV читает матрицу в формате WriteCSV. Порядок P определяется по
+// числу столбцов заголовка, длины осей — по наибольшим индексам; строки
+// могут идти в любом порядке, но каждый элемент должен встречаться ровно
+// один раз, иначе возвращается ErrDataLength. Индексы от MaxUint32 дают
+// ErrIndexOutOfRange, прочие неверные строки — ErrInvalidFormat
+func ReadCSV[T Number](r io.Reader) (*Matrix[T], error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("%w: csv header: %w", ErrInvalidFormat, err)
+	}
+	p := len(header) - 1
+	if p < 0 || header[p] != "value" {
+		return nil, fmt.Errorf("%w: csv header must end with \"value\"", ErrInvalidFormat)
+	}
+
+	var indices []int
+	var values []T
+	shape := make([]int, p)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		}
+		line, _ := cr.FieldPos(0)
+		for axis, field := range record[:p] {
+			i, err := strconv.Atoi(field)
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("%w: line %d: index %q", ErrInvalidFormat, line, field)
+			}
+			// Длина оси не превосходит MaxUint32, поэтому i+1 не переполняется
+			if i >= math.MaxUint32 {
+				return nil, fmt.Errorf("%w: line %d: index %d on axis %d", ErrIndexOutOfRange, line, i, axis)
+			}
+			shape[axis] = max(shape[axis], i+1)
+			indices = append(indices, i)
+		}
+		v, err := parseElement[T](record[p])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		values = append(values, v)
+	}
+
+	size, err := checkShape(shape)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != size {
+		return nil, fmt.Errorf("%w: got %d rows, want %d for shape %v", ErrDataLength, len(values), size, shape)
+	}
+	m := fromShape(shape, make([]T, size))
+	seen := make([]bool, size)
+	for k, v := range values {
+		offset, err := m.Offset(indices[k*p : (k+1)*p]...)
+		if err != nil {
+			return nil, err
+		}
+		if seen[offset] {
+			return nil, fmt.Errorf("%w: duplicate index %v", ErrDataLength, indices[k*p:(k+1)*p])
+		}
+		seen[offset] = true
+		m.Data[offset] = v
+	}
+	return m, nil
+}
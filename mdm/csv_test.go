@@ -0,0 +1,68 @@
+package mdm
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestCSV тестирует запись и чтение длинного формата CSV
+func TestCSV(t *testing.T) {
+	m := newSequence(t, 2, 3)
+	var buf bytes.Buffer
+	if err := m.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	expected := "i1,i2,value\n0,0,0\n0,1,1\n0,2,2\n1,0,3\n1,1,4\n1,2,5\n"
+	if buf.String() != expected {
+		t.Errorf("Got %q, expected %q", buf.String(), expected)
+	}
+	got, err := ReadCSV[int64](&buf)
+	if err != nil {
+		t.Fatalf("ReadCSV: %v", err)
+	}
+	compareMatrices(t, m, got)
+
+	// Строки в произвольном порядке и кубическая форма
+	cubic, err := ReadCSV[float64](strings.NewReader("i,j,value\n1,1,4\n0,0,1.5\n1,0,3\n0,1,2\n"))
+	if err != nil {
+		t.Fatalf("ReadCSV: %v", err)
+	}
+	if cubic.X != 2 || cubic.P != 2 || cubic.At(0, 0) != 1.5 || cubic.At(1, 0) != 3 {
+		t.Errorf("Got %+v", cubic)
+	}
+
+	c := CreateMatrix[complex64](1, 0)
+	c.Data[0] = 1 - 1i
+	buf.Reset()
+	c.WriteCSV(&buf)
+	gotComplex, err := ReadCSV[complex64](&buf)
+	if err != nil {
+		t.Fatalf("ReadCSV: %v", err)
+	}
+	compareMatrices(t, c, gotComplex)
+}
+
+// TestCSVErrors тестирует проверку CSV
+func TestCSVErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		target error
+	}{
+		{"", ErrInvalidFormat},
+		{"i1,v\n", ErrInvalidFormat},
+		{"i1,value\n-1,2\n", ErrInvalidFormat},
+		{"i1,value\n0,x\n", ErrInvalidFormat},
+		{"i1,value\n0,1,2\n", ErrInvalidFormat},
+		{"i1,value\n0,1\n2,1\n", ErrDataLength},
+		{"i1,value\n0,1\n0,2\n", ErrDataLength},
+		{"i1,value\n4294967295,1\n", ErrIndexOutOfRange},
+		{"i1,i2,value\n0,9223372036854775807,1\n", ErrIndexOutOfRange},
+	}
+	for _, tt := range tests {
+		if _, err := ReadCSV[int32](strings.NewReader(tt.input)); !errors.Is(err, tt.target) {
+			t.Errorf("%q: expected %v, got %v", tt.input, tt.target, err)
+		}
+	}
+}
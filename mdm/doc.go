@@ -0,0 +1,10 @@
+// Package mdm реализует многомерные матрицы и их (λ,μ)-умножение по Соколову.
+//
+// Матрица порядка P с размерностью X хранится в Matrix.Data в порядке C
+// (последний индекс меняется быстрее всего). Оси могут иметь разные длины
+// (см. NewShaped); тогда при умножении совпадать должны только скоттовы
+// и кэлиевы оси операндов. При (λ,μ)-умножении левый
+// операнд рассматривается как [l, s, c], правый — как [s, c, m], где s —
+// λ скоттовых (скалярных) индексов, c — μ кэлиевых (суммируемых) индексов;
+// результат имеет вид [l, s, m].
+package mdm
@@ -0,0 +1,351 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidSpec возвращается при синтаксической или смысловой ошибке в записи Einsum
+var ErrInvalidSpec = errors.New("mdm: invalid einsum spec")
+
+// Einsum вычисляет свёртку операндов, заданную в нотации Эйнштейна,
+// например "ijk,jkl->ijl". Индексы обозначаются латинскими буквами;
+// без "->" результат содержит индексы, встречающиеся ровно один раз,
+// в алфавитном порядке.
+//
+// Свёртка двух операндов сводится к (λ,μ)-умножению: общие индексы,
+// присутствующие в результате, становятся скоттовыми, остальные общие —
+// кэлиевыми, а оси операндов переставляются в порядок [l, s, c] и [s, c, m].
+// Записи, которые так не сводятся (повторяющиеся индексы в операнде,
+// суммирование по индексу одного операнда, единственный операнд),
+// вычисляются общим циклом. Три и более операндов сворачиваются попарно
+// в порядке, выбранном EinsumPath
+func Einsum[T Number](spec string, operands ...*Matrix[T]) (*Matrix[T], error) {
+	inputs, output, err := parseEinsum(spec, len(operands))
+	if err != nil {
+		return nil, err
+	}
+	shapes := make([][]int, len(operands))
+	for i, op := range operands {
+		if err := op.validate(); err != nil {
+			return nil, fmt.Errorf("operand %d: %w", i, err)
+		}
+		shapes[i] = op.shape()
+	}
+	sizes, err := labelSizes(inputs, shapes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(operands) {
+	case 1:
+		return einsumLoop(operands, inputs, output, sizes)
+	case 2:
+		return contractPair(operands[0], inputs[0], operands[1], inputs[1], output, sizes)
+	}
+
+	ops := slices.Clone(operands)
+	labels := slices.Clone(inputs)
+	for _, pair := range greedyPath(labels, output, sizes) {
+		i, j := pair[0], pair[1]
+		keep := keptLabels(labels, i, j, output)
+		r, err := contractPair(ops[i], labels[i], ops[j], labels[j], keep, sizes)
+		if err != nil {
+			return nil, err
+		}
+		ops = replacePair(ops, i, j, r)
+		labels = replacePair(labels, i, j, keep)
+	}
+	if labels[0] == output {
+		return ops[0], nil
+	}
+	return einsumLoop(ops, labels, output, sizes)
+}
+
+// EinsumPath возвращает порядок попарных свёрток, который Einsum использует
+// для операндов с формами shapes. Каждая пара — позиции двух операндов в
+// текущем списке; они удаляются из него, а результат добавляется в конец.
+// На каждом шаге жадно выбирается пара с наименьшим промежуточным
+// результатом, при равенстве — с наименьшим числом умножений
+func EinsumPath(spec string, shapes ...[]int) ([][2]int, error) {
+	inputs, output, err := parseEinsum(spec, len(shapes))
+	if err != nil {
+		return nil, err
+	}
+	sizes, err := labelSizes(inputs, shapes)
+	if err != nil {
+		return nil, err
+	}
+	return greedyPath(inputs, output, sizes), nil
+}
+
+// parseEinsum разбирает запись на индексы операндов и результата
+func parseEinsum(spec string, operands int) ([]string, string, error) {
+	spec = strings.ReplaceAll(spec, " ", "")
+	lhs, output, explicit := strings.Cut(spec, "->")
+	inputs := strings.Split(lhs, ",")
+	if len(inputs) != operands {
+		return nil, "", fmt.Errorf("%w: %q has %d operands, got %d", ErrInvalidSpec, spec, len(inputs), operands)
+	}
+
+	counts := make(map[byte]int)
+	for _, in := range inputs {
+		for i := 0; i < len(in); i++ {
+			if !isLabel(in[i]) {
+				return nil, "", fmt.Errorf("%w: %q has invalid label %q", ErrInvalidSpec, spec, in[i])
+			}
+			counts[in[i]]++
+		}
+	}
+
+	if !explicit {
+		var implicit []byte
+		for label, n := range counts {
+			if n == 1 {
+				implicit = append(implicit, label)
+			}
+		}
+		slices.Sort(implicit)
+		return inputs, string(implicit), nil
+	}
+	for i := 0; i < len(output); i++ {
+		switch {
+		case !isLabel(output[i]):
+			return nil, "", fmt.Errorf("%w: %q has invalid label %q", ErrInvalidSpec, spec, output[i])
+		case counts[output[i]] == 0:
+			return nil, "", fmt.Errorf("%w: %q: output label %q is not in inputs", ErrInvalidSpec, spec, output[i])
+		case strings.IndexByte(output[i+1:], output[i]) >= 0:
+			return nil, "", fmt.Errorf("%w: %q: output label %q is repeated", ErrInvalidSpec, spec, output[i])
+		}
+	}
+	return inputs, output, nil
+}
+
+func isLabel(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// labelSizes сопоставляет каждому индексу длину оси и проверяет согласованность форм
+func labelSizes(inputs []string, shapes [][]int) (map[byte]int, error) {
+	sizes := make(map[byte]int)
+	for k, in := range inputs {
+		if len(in) != len(shapes[k]) {
+			return nil, fmt.Errorf("%w: operand %d has order %d, spec %q", ErrDimensionMismatch, k, len(shapes[k]), in)
+		}
+		for axis := 0; axis < len(in); axis++ {
+			n, ok := sizes[in[axis]]
+			if ok && n != shapes[k][axis] {
+				return nil, fmt.Errorf("%w: label %q has lengths %d and %d",
+					ErrDimensionMismatch, in[axis], n, shapes[k][axis])
+			}
+			sizes[in[axis]] = shapes[k][axis]
+		}
+	}
+	return sizes, nil
+}
+
+// keptLabels возвращает индексы свёртки операндов i и j, которые нужны
+// результату или оставшимся операндам, в порядке появления
+func keptLabels(labels []string, i, j int, output string) string {
+	var keep []byte
+	for _, c := range []byte(labels[i] + labels[j]) {
+		if slices.Contains(keep, c) {
+			continue
+		}
+		needed := strings.IndexByte(output, c) >= 0
+		for k, other := range labels {
+			if k != i && k != j && strings.IndexByte(other, c) >= 0 {
+				needed = true
+			}
+		}
+		if needed {
+			keep = append(keep, c)
+		}
+	}
+	return string(keep)
+}
+
+// greedyPath выбирает порядок попарных свёрток
+func greedyPath(labels []string, output string, sizes map[byte]int) [][2]int {
+	labels = slices.Clone(labels)
+	var path [][2]int
+	for len(labels) > 1 {
+		best, bestSize, bestFlops := [2]int{}, -1, 0
+		for i := 0; i < len(labels); i++ {
+			for j := i + 1; j < len(labels); j++ {
+				keep := keptLabels(labels, i, j, output)
+				size := labelsVolume(keep, sizes)
+				flops := labelsVolume(labels[i]+labels[j], sizes)
+				if bestSize < 0 || size < bestSize || size == bestSize && flops < bestFlops {
+					best, bestSize, bestFlops = [2]int{i, j}, size, flops
+				}
+			}
+		}
+		labels = replacePair(labels, best[0], best[1], keptLabels(labels, best[0], best[1], output))
+		path = append(path, best)
+	}
+	return path
+}
+
+// replacePair удаляет элементы i < j и добавляет в конец элемент r
+func replacePair[E any](list []E, i, j int, r E) []E {
+	list = slices.Delete(list, j, j+1)
+	list = slices.Delete(list, i, i+1)
+	return append(list, r)
+}
+
+// labelsVolume возвращает число наборов значений различных индексов labels
+// с насыщением при переполнении
+func labelsVolume(labels string, sizes map[byte]int) int {
+	var seen []byte
+	volume := 1
+	for _, c := range []byte(labels) {
+		if slices.Contains(seen, c) {
+			continue
+		}
+		seen = append(seen, c)
+		if n := sizes[c]; n != 0 && volume > math.MaxInt/n {
+			volume = math.MaxInt
+		} else {
+			volume *= n
+		}
+	}
+	return volume
+}
+
+// contractPair сворачивает два операнда в результат с индексами out через
+// (λ,μ)-умножение, если это возможно, иначе общим циклом
+func contractPair[T Number](a *Matrix[T], la string, b *Matrix[T], lb string, out string, sizes map[byte]int) (*Matrix[T], error) {
+	var l, s, c, m []byte
+	mappable := hasUniqueLabels(la) && hasUniqueLabels(lb)
+	for _, x := range []byte(la) {
+		inB, inOut := strings.IndexByte(lb, x) >= 0, strings.IndexByte(out, x) >= 0
+		switch {
+		case inB && inOut:
+			s = append(s, x)
+		case inB:
+			c = append(c, x)
+		case inOut:
+			l = append(l, x)
+		default:
+			mappable = false
+		}
+	}
+	for _, x := range []byte(lb) {
+		inA, inOut := strings.IndexByte(la, x) >= 0, strings.IndexByte(out, x) >= 0
+		switch {
+		case !inA && inOut:
+			m = append(m, x)
+		case !inA:
+			mappable = false
+		}
+	}
+	if !mappable {
+		return einsumLoop([]*Matrix[T]{a, b}, []string{la, lb}, out, sizes)
+	}
+
+	lhs, err := permuteLabels(a, la, string(slices.Concat(l, s, c)))
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := permuteLabels(b, lb, string(slices.Concat(s, c, m)))
+	if err != nil {
+		return nil, err
+	}
+	r, err := lhs.MultiplyChecked(uint32(len(s)), uint32(len(c)), rhs)
+	if err != nil {
+		return nil, err
+	}
+	return permuteLabels(r, string(slices.Concat(l, s, m)), out)
+}
+
+// permuteLabels переставляет оси матрицы с индексами from в порядок to
+func permuteLabels[T Number](a *Matrix[T], from, to string) (*Matrix[T], error) {
+	if from == to {
+		return a, nil
+	}
+	perm := make([]int, len(to))
+	for i := 0; i < len(to); i++ {
+		perm[i] = strings.IndexByte(from, to[i])
+	}
+	return a.Transpose(perm)
+}
+
+func hasUniqueLabels(labels string) bool {
+	for i := 0; i < len(labels); i++ {
+		if strings.IndexByte(labels[i+1:], labels[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// einsumLoop вычисляет произвольную запись непосредственно: перебирает
+// все значения индексов результата и суммируемых индексов, поддерживая
+// смещения в каждом операнде по страйдам
+func einsumLoop[T Number](ops []*Matrix[T], inputs []string, output string, sizes map[byte]int) (*Matrix[T], error) {
+	all := []byte(output)
+	for _, in := range inputs {
+		for _, c := range []byte(in) {
+			if !slices.Contains(all, c) {
+				all = append(all, c)
+			}
+		}
+	}
+	dims := make([]int, len(all))
+	for i, c := range all {
+		dims[i] = sizes[c]
+	}
+	total, ok := shapeSize(dims)
+	if !ok {
+		return nil, fmt.Errorf("%w: einsum iteration space %v", ErrSizeOverflow, dims)
+	}
+
+	// strides[k][i] — шаг смещения операнда k при увеличении индекса all[i];
+	// повторяющиеся индексы операнда складывают страйды своих осей
+	strides := make([][]int, len(ops)+1)
+	for k, op := range ops {
+		strides[k] = make([]int, len(all))
+		opStrides := shapeStrides(op.shape())
+		for axis := 0; axis < len(inputs[k]); axis++ {
+			strides[k][slices.Index(all, inputs[k][axis])] += opStrides[axis]
+		}
+	}
+	outShape := dims[:len(output)]
+	outStrides := shapeStrides(outShape)
+	strides[len(ops)] = append(outStrides, make([]int, len(all)-len(output))...)
+
+	size, err := checkShape(outShape)
+	if err != nil {
+		return nil, err
+	}
+	result := fromShape(outShape, make([]T, size))
+
+	offsets := make([]int, len(ops)+1)
+	index := make([]int, len(all))
+	for range total {
+		product := T(1)
+		for k, op := range ops {
+			product *= op.Data[offsets[k]]
+		}
+		result.Data[offsets[len(ops)]] += product
+
+		for axis := len(index) - 1; axis >= 0; axis-- {
+			if index[axis] < dims[axis]-1 {
+				index[axis]++
+				for k := range offsets {
+					offsets[k] += strides[k][axis]
+				}
+				break
+			}
+			for k := range offsets {
+				offsets[k] -= index[axis] * strides[k][axis]
+			}
+			index[axis] = 0
+		}
+	}
+	return result, nil
+}
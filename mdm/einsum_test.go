@@ -0,0 +1,165 @@
+package mdm
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// filledShaped создаёт матрицу формы shape с небольшими целыми элементами
+func filledShaped(t *testing.T, seed int, shape ...int) *Matrix[int64] {
+	t.Helper()
+	m, err := NewShaped[int64](shape...)
+	if err != nil {
+		t.Fatalf("NewShaped: %v", err)
+	}
+	for i := range m.Data {
+		m.Data[i] = int64((i*seed+3)%7) - 3
+	}
+	return m
+}
+
+// TestEinsumMatchesMultiplication тестирует сведение свёрток к (λ,μ)-умножению
+func TestEinsumMatchesMultiplication(t *testing.T) {
+	a := filledShaped(t, 5, 2, 3, 4)
+	b := filledShaped(t, 3, 3, 4, 5)
+
+	got, err := Einsum("ijk,jkl->ijl", a, b)
+	if err != nil {
+		t.Fatalf("Einsum: %v", err)
+	}
+	compareMatrices(t, a.Multiplication(1, 1, b), got)
+
+	got, err = Einsum("ijk,jkl->il", a, b)
+	if err != nil {
+		t.Fatalf("Einsum: %v", err)
+	}
+	compareMatrices(t, a.Multiplication(0, 2, b), got)
+
+	// Переставленные оси операндов и результата
+	bt, _ := b.Transpose([]int{2, 0, 1})
+	got, err = Einsum("ijk,ljk->lji", a, bt)
+	if err != nil {
+		t.Fatalf("Einsum: %v", err)
+	}
+	expected, _ := a.Multiplication(1, 1, b).Transpose([]int{2, 1, 0})
+	compareMatrices(t, expected, got)
+}
+
+// TestEinsumLoop тестирует записи, вычисляемые общим циклом
+func TestEinsumLoop(t *testing.T) {
+	m := CreateMatrix[int64](3, 2)
+	copy(m.Data, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	v := CreateMatrix[int64](3, 1)
+	copy(v.Data, []int64{1, 0, -1})
+
+	tests := []struct {
+		spec     string
+		operands []*Matrix[int64]
+		shape    []int
+		expected []int64
+	}{
+		{"ii->", []*Matrix[int64]{m}, []int{}, []int64{15}},
+		{"ii->i", []*Matrix[int64]{m}, []int{3}, []int64{1, 5, 9}},
+		{"ij->ji", []*Matrix[int64]{m}, []int{3, 3}, []int64{1, 4, 7, 2, 5, 8, 3, 6, 9}},
+		{"ij->", []*Matrix[int64]{m}, []int{}, []int64{45}},
+		{"ij", []*Matrix[int64]{m}, []int{3, 3}, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"ij,j->i", []*Matrix[int64]{m, v}, []int{3}, []int64{-2, -2, -2}},
+		// Суммирование по k только в первом операнде не сводится к умножению
+		{"ik,j->ij", []*Matrix[int64]{m, v}, []int{3, 3}, []int64{6, 0, -6, 15, 0, -15, 24, 0, -24}},
+		{"ii,i->i", []*Matrix[int64]{m, v}, []int{3}, []int64{1, 0, -9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := Einsum(tt.spec, tt.operands...)
+			if err != nil {
+				t.Fatalf("Einsum: %v", err)
+			}
+			if !slices.Equal(got.Shape(), tt.shape) || !slices.Equal(got.Data, tt.expected) {
+				t.Errorf("got shape %v data %v, expected shape %v data %v", got.Shape(), got.Data, tt.shape, tt.expected)
+			}
+		})
+	}
+}
+
+// TestEinsumPairPaths тестирует совпадение (λ,μ)-пути и общего цикла
+func TestEinsumPairPaths(t *testing.T) {
+	a := filledShaped(t, 5, 2, 3, 4)
+	b := filledShaped(t, 3, 4, 3, 2)
+	for _, spec := range []string{"ijk,kjl->ijl", "ijk,kjl->lji", "ijk,kji->j", "ijk,klm->ijlm", "ijk,kjl"} {
+		t.Run(spec, func(t *testing.T) {
+			inputs, output, err := parseEinsum(spec, 2)
+			if err != nil {
+				t.Fatalf("parseEinsum: %v", err)
+			}
+			sizes, _ := labelSizes(inputs, [][]int{a.Shape(), b.Shape()})
+			expected, err := einsumLoop([]*Matrix[int64]{a, b}, inputs, output, sizes)
+			if err != nil {
+				t.Fatalf("einsumLoop: %v", err)
+			}
+			got, err := Einsum(spec, a, b)
+			if err != nil {
+				t.Fatalf("Einsum: %v", err)
+			}
+			compareMatrices(t, expected, got)
+		})
+	}
+}
+
+// TestEinsumMultiOperand тестирует свёртку нескольких операндов
+func TestEinsumMultiOperand(t *testing.T) {
+	a := filledShaped(t, 5, 2, 50)
+	b := filledShaped(t, 3, 50, 50)
+	c := filledShaped(t, 2, 50, 1)
+
+	path, err := EinsumPath("ij,jk,kl->il", a.Shape(), b.Shape(), c.Shape())
+	if err != nil {
+		t.Fatalf("EinsumPath: %v", err)
+	}
+	if expected := [][2]int{{1, 2}, {0, 1}}; !slices.Equal(path, expected) {
+		t.Errorf("EinsumPath: got %v, expected %v", path, expected)
+	}
+
+	got, err := Einsum("ij,jk,kl->il", a, b, c)
+	if err != nil {
+		t.Fatalf("Einsum: %v", err)
+	}
+	compareMatrices(t, a.Multiplication(0, 1, b).Multiplication(0, 1, c), got)
+
+	// Общий индекс трёх операндов и перестановка результата
+	x := filledShaped(t, 5, 3, 4)
+	y := filledShaped(t, 3, 3, 4)
+	z := filledShaped(t, 2, 3, 2)
+	got, err = Einsum("ij,ij,ik->ki", x, y, z)
+	if err != nil {
+		t.Fatalf("Einsum: %v", err)
+	}
+	inputs, output, _ := parseEinsum("ij,ij,ik->ki", 3)
+	sizes, _ := labelSizes(inputs, [][]int{x.Shape(), y.Shape(), z.Shape()})
+	expected, _ := einsumLoop([]*Matrix[int64]{x, y, z}, inputs, output, sizes)
+	compareMatrices(t, expected, got)
+}
+
+// TestEinsumErrors тестирует проверку записи и операндов
+func TestEinsumErrors(t *testing.T) {
+	a := filledShaped(t, 1, 2, 3)
+	b := filledShaped(t, 1, 4, 5)
+	tests := []struct {
+		spec   string
+		ops    []*Matrix[int64]
+		target error
+	}{
+		{"ij,jk->ik", []*Matrix[int64]{a}, ErrInvalidSpec},
+		{"i1,jk->ik", []*Matrix[int64]{a, b}, ErrInvalidSpec},
+		{"ij,jk->iz", []*Matrix[int64]{a, b}, ErrInvalidSpec},
+		{"ij,jk->ii", []*Matrix[int64]{a, b}, ErrInvalidSpec},
+		{"ij,jk->ik", []*Matrix[int64]{a, b}, ErrDimensionMismatch},
+		{"ijk,kl->il", []*Matrix[int64]{a, b}, ErrDimensionMismatch},
+		{"ij,kl->ik", []*Matrix[int64]{a, nil}, ErrNilMatrix},
+	}
+	for _, tt := range tests {
+		if _, err := Einsum(tt.spec, tt.ops...); !errors.Is(err, tt.target) {
+			t.Errorf("Einsum(%q): expected %v, got %v", tt.spec, tt.target, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Ошибки проверки операндов (λ,μ)-умножения. Проверяются через errors.Is.
+var (
+	ErrNilMatrix         = errors.New("mdm: nil operand")
+	ErrDimensionMismatch = errors.New("mdm: dimension mismatch")
+	ErrInvalidLambdaMu   = errors.New("mdm: invalid lambda/mu")
+	ErrDataLength        = errors.New("mdm: data length does not match shape")
+	ErrSizeOverflow      = errors.New("mdm: size overflows int")
+	ErrInvalidStrategy   = errors.New("mdm: invalid strategy")
+	ErrInvalidShape      = errors.New("mdm: invalid shape")
+	ErrIndexOutOfRange   = errors.New("mdm: index out of range")
+	ErrInvalidAxis       = errors.New("mdm: invalid axis")
+)
+
+// checkedPow вычисляет x^p в целых числах; ok == false при переполнении int
+func checkedPow(x, p uint32) (int, bool) {
+	switch {
+	case p == 0 || x == 1:
+		return 1, true
+	case x == 0:
+		return 0, true
+	}
+	result := 1
+	for i := uint32(0); i < p; i++ {
+		if result > math.MaxInt/int(x) {
+			return 0, false
+		}
+		result *= int(x)
+	}
+	return result, true
+}
+
+// validate проверяет, что матрица не nil и длина Data равна числу элементов формы
+func (m *Matrix[T]) validate() error {
+	if m == nil {
+		return ErrNilMatrix
+	}
+	if m.dims != nil {
+		size, err := checkShape(m.dims)
+		if err != nil {
+			return err
+		}
+		if len(m.Data) != size {
+			return fmt.Errorf("%w: got %d, want %d for shape %v", ErrDataLength, len(m.Data), size, m.dims)
+		}
+		return nil
+	}
+	size, ok := checkedPow(m.X, m.P)
+	if !ok {
+		return fmt.Errorf("%w: %d^%d", ErrSizeOverflow, m.X, m.P)
+	}
+	if len(m.Data) != size {
+		return fmt.Errorf("%w: got %d, want %d^%d = %d", ErrDataLength, len(m.Data), m.X, m.P, size)
+	}
+	return nil
+}
+
+// validateOperands проверяет операнды (λ,μ)-умножения и возвращает
+// форму и число элементов результирующей матрицы
+func validateOperands[T Number](lhs, rhs *Matrix[T], lambda, mu uint32) ([]int, int, error) {
+	if err := lhs.validate(); err != nil {
+		return nil, 0, fmt.Errorf("lhs: %w", err)
+	}
+	if err := rhs.validate(); err != nil {
+		return nil, 0, fmt.Errorf("rhs: %w", err)
+	}
+	return resultShape(lhs.shape(), rhs.shape(), lambda, mu)
+}
+
+// resultShape проверяет согласованность форм операндов (λ,μ)-умножения
+// и возвращает форму и число элементов результирующей матрицы.
+// Совпадать должны только скоттовы и кэлиевы оси: [l, s, c] и [s, c, m]
+func resultShape(lhsShape, rhsShape []int, lambda, mu uint32) ([]int, int, error) {
+	// Сравнение в uint64 исключает переполнение суммы lambda+mu
+	sum := uint64(lambda) + uint64(mu)
+	if sum > uint64(len(lhsShape)) || sum > uint64(len(rhsShape)) {
+		return nil, 0, fmt.Errorf("%w: lambda=%d, mu=%d, lhs P=%d, rhs P=%d",
+			ErrInvalidLambdaMu, lambda, mu, len(lhsShape), len(rhsShape))
+	}
+
+	l := len(lhsShape) - int(sum)
+	lhsSC := lhsShape[l:]
+	rhsSC := rhsShape[:sum]
+	for i := range lhsSC {
+		if lhsSC[i] != rhsSC[i] {
+			return nil, 0, fmt.Errorf("%w: lhs axis %d has length %d, rhs axis %d has length %d",
+				ErrDimensionMismatch, l+i, lhsSC[i], i, rhsSC[i])
+		}
+	}
+
+	shape := make([]int, 0, l+int(lambda)+len(rhsShape)-int(sum))
+	shape = append(shape, lhsShape[:l+int(lambda)]...)
+	shape = append(shape, rhsShape[sum:]...)
+	if len(shape) > math.MaxUint32 {
+		return nil, 0, fmt.Errorf("%w: result order %d", ErrSizeOverflow, len(shape))
+	}
+	size, ok := shapeSize(shape)
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: result shape %v", ErrSizeOverflow, shape)
+	}
+	return shape, size, nil
+}
@@ -0,0 +1,133 @@
+package mdm
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestMultiplyCheckedErrors тестирует типизированные ошибки проверяемого API
+func TestMultiplyCheckedErrors(t *testing.T) {
+	valid := CreateMatrix[uint32](3, 2)
+
+	tests := []struct {
+		name       string
+		lhs, rhs   *Matrix[uint32]
+		lambda, mu uint32
+		expected   error
+	}{
+		{
+			name:     "nil lhs",
+			lhs:      nil,
+			rhs:      valid,
+			expected: ErrNilMatrix,
+		},
+		{
+			name:     "nil rhs",
+			lhs:      valid,
+			rhs:      nil,
+			expected: ErrNilMatrix,
+		},
+		{
+			name:     "X mismatch",
+			lhs:      valid,
+			rhs:      CreateMatrix[uint32](2, 2),
+			mu:       1,
+			expected: ErrDimensionMismatch,
+		},
+		{
+			name:     "lambda+mu exceeds lhs P",
+			lhs:      CreateMatrix[uint32](3, 1),
+			rhs:      valid,
+			lambda:   1,
+			mu:       1,
+			expected: ErrInvalidLambdaMu,
+		},
+		{
+			name:     "lambda+mu exceeds rhs P",
+			lhs:      valid,
+			rhs:      valid,
+			lambda:   2,
+			mu:       1,
+			expected: ErrInvalidLambdaMu,
+		},
+		{
+			name:     "lambda+mu overflows uint32",
+			lhs:      valid,
+			rhs:      valid,
+			lambda:   math.MaxUint32,
+			mu:       1,
+			expected: ErrInvalidLambdaMu,
+		},
+		{
+			name:     "short data",
+			lhs:      &Matrix[uint32]{X: 3, P: 2, Data: []uint32{1, 2, 3}},
+			rhs:      valid,
+			expected: ErrDataLength,
+		},
+		{
+			name:     "operand size overflow",
+			lhs:      &Matrix[uint32]{X: 1 << 16, P: 4},
+			rhs:      valid,
+			expected: ErrSizeOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.lhs.MultiplyChecked(tt.lambda, tt.mu, tt.rhs)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("MultiplyChecked error = %v, expected %v", err, tt.expected)
+			}
+			if result != nil {
+				t.Errorf("MultiplyChecked returned non-nil result on error")
+			}
+
+			result, err = tt.lhs.ParallelMultiplyChecked(tt.lambda, tt.mu, tt.rhs)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("ParallelMultiplyChecked error = %v, expected %v", err, tt.expected)
+			}
+			if result != nil {
+				t.Errorf("ParallelMultiplyChecked returned non-nil result on error")
+			}
+		})
+	}
+}
+
+// TestCheckedPow тестирует целочисленное возведение в степень с контролем переполнения
+func TestCheckedPow(t *testing.T) {
+	tests := []struct {
+		x, p     uint32
+		expected int
+		ok       bool
+	}{
+		{x: 3, p: 2, expected: 9, ok: true},
+		{x: 10, p: 0, expected: 1, ok: true},
+		{x: 0, p: 5, expected: 0, ok: true},
+		{x: 1, p: math.MaxUint32, expected: 1, ok: true},
+		{x: 2, p: 62, expected: 1 << 62, ok: true},
+		{x: 2, p: 63, ok: false},
+		{x: 1 << 16, p: 4, ok: false},
+	}
+
+	for _, tt := range tests {
+		result, ok := checkedPow(tt.x, tt.p)
+		if ok != tt.ok || (ok && result != tt.expected) {
+			t.Errorf("checkedPow(%d, %d) = (%d, %v), expected (%d, %v)",
+				tt.x, tt.p, result, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+// TestLegacyMultiplicationPanics тестирует, что непроверяемый API паникует с типизированной ошибкой
+func TestLegacyMultiplicationPanics(t *testing.T) {
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, ErrDimensionMismatch) {
+			t.Errorf("Expected panic with ErrDimensionMismatch, got %v", r)
+		}
+	}()
+
+	CreateMatrix[uint32](3, 1).Multiplication(0, 1, CreateMatrix[uint32](2, 1))
+}
@@ -0,0 +1,119 @@
+package mdm
+
+import "fmt"
+
+// Strategy задаёт алгоритм вычисления (λ,μ)-произведения по плану
+type Strategy int
+
+const (
+	// StrategyAuto — выбор алгоритма по размерам блоков плана
+	StrategyAuto Strategy = iota
+	// StrategyDirect — скалярное произведение для каждой ячейки результата
+	StrategyDirect
+	// StrategyGEMM — пакет из X^λ обычных матричных произведений
+	// (X^l × X^μ)·(X^μ × X^m) с блочным обходом для локальности кэша
+	StrategyGEMM
+	// StrategyOdometer — исходный поэлементный обход многомерных индексов
+	StrategyOdometer
+)
+
+// String возвращает название стратегии
+func (s Strategy) String() string {
+	switch s {
+	case StrategyAuto:
+		return "auto"
+	case StrategyDirect:
+		return "direct"
+	case StrategyGEMM:
+		return "gemm"
+	case StrategyOdometer:
+		return "odometer"
+	default:
+		return fmt.Sprintf("Strategy(%d)", int(s))
+	}
+}
+
+// Размеры блоков GEMM по строкам результата, суммируемому индексу и столбцам
+const (
+	gemmBlockRows = 32
+	gemmBlockK    = 128
+	gemmBlockCols = 256
+)
+
+// gemmMinCols — минимальный размер правого свободного блока X^m,
+// начиная с которого StrategyAuto выбирает GEMM
+const gemmMinCols = 8
+
+// autoStrategy выбирает стратегию по размерам блоков плана. Блочный GEMM
+// выигрывает, когда строки правой матрицы достаточно длинные для
+// непрерывного внутреннего цикла; при коротких строках накладные расходы
+// на блоки превышают выигрыш от локальности
+func autoStrategy(mSize int) Strategy {
+	if mSize >= gemmMinCols {
+		return StrategyGEMM
+	}
+	return StrategyDirect
+}
+
+// gemmTasks возвращает число независимых задач GEMM: блоков строк во всех пакетах
+func (p *Plan[T]) gemmTasks() int {
+	return p.sSize * ((p.lSize + gemmBlockRows - 1) / gemmBlockRows)
+}
+
+// gemmCells возвращает число ячеек результата в задачах GEMM [start, end);
+// последний блок строк пакета может быть неполным
+func (p *Plan[T]) gemmCells(start, end int) int {
+	rowBlocks := (p.lSize + gemmBlockRows - 1) / gemmBlockRows
+	var rows int
+	for t := start; t < end; t++ {
+		i0 := (t % rowBlocks) * gemmBlockRows
+		rows += min(i0+gemmBlockRows, p.lSize) - i0
+	}
+	return rows * p.mSize
+}
+
+// gemmRange выполняет задачи GEMM [start, end). Задача t соответствует пакету
+// s = t / rowBlocks и блоку строк t % rowBlocks
+func (p *Plan[T]) gemmRange(lhs, rhs, out []T, start, end int) {
+	rowBlocks := (p.lSize + gemmBlockRows - 1) / gemmBlockRows
+	for t := start; t < end; t++ {
+		s := t / rowBlocks
+		i0 := (t % rowBlocks) * gemmBlockRows
+		p.gemmBlock(lhs, rhs, out, s, i0, min(i0+gemmBlockRows, p.lSize))
+	}
+}
+
+// gemmBlock вычисляет строки [i0, i1) пакета s: C_s = A_s · B_s, где
+// A_s[i][k] = lhs[(i*S + s)*C + k], B_s[k][j] = rhs[(s*C + k)*M + j],
+// C_s[i][j] = out[(i*S + s)*M + j]. Слагаемые накапливаются по возрастанию k
+// в том же порядке, что и в StrategyDirect
+func (p *Plan[T]) gemmBlock(lhs, rhs, out []T, s, i0, i1 int) {
+	cSize, mSize := p.cSize, p.mSize
+	lhsRowStride := p.sSize * cSize
+	outRowStride := p.sSize * mSize
+	lhsBase := s * cSize
+	rhsBase := s * p.rhsSStride
+	outBase := s * mSize
+
+	for i := i0; i < i1; i++ {
+		clear(out[outBase+i*outRowStride : outBase+i*outRowStride+mSize])
+	}
+
+	for k0 := 0; k0 < cSize; k0 += gemmBlockK {
+		k1 := min(k0+gemmBlockK, cSize)
+		for j0 := 0; j0 < mSize; j0 += gemmBlockCols {
+			j1 := min(j0+gemmBlockCols, mSize)
+			for i := i0; i < i1; i++ {
+				lhsRow := lhs[lhsBase+i*lhsRowStride : lhsBase+i*lhsRowStride+cSize]
+				outRow := out[outBase+i*outRowStride+j0 : outBase+i*outRowStride+j1]
+				for k := k0; k < k1; k++ {
+					a := lhsRow[k]
+					rhsRow := rhs[rhsBase+k*mSize+j0 : rhsBase+k*mSize+j1]
+					for j, b := range rhsRow {
+						outRow[j] += a * b
+					}
+				}
+			}
+		}
+	}
+}
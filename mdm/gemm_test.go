@@ -0,0 +1,137 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestStrategiesAgree тестирует совпадение результатов всех стратегий плана
+func TestStrategiesAgree(t *testing.T) {
+	X := uint32(4)
+	strategies := []Strategy{StrategyDirect, StrategyGEMM, StrategyOdometer}
+
+	for lhsP := uint32(1); lhsP <= 4; lhsP++ {
+		for rhsP := uint32(1); rhsP <= 4; rhsP++ {
+			lhs := CreateMatrix[float64](X, lhsP)
+			for i := range lhs.Data {
+				lhs.Data[i] = float64(i%7)*0.25 - 1
+			}
+			rhs := CreateMatrix[float64](X, rhsP)
+			for i := range rhs.Data {
+				rhs.Data[i] = float64((i+3)%11)*0.5 - 2
+			}
+
+			for lambda := uint32(0); lambda <= min(lhsP, rhsP); lambda++ {
+				for mu := uint32(0); lambda+mu <= min(lhsP, rhsP); mu++ {
+					name := fmt.Sprintf("lhsP=%d_rhsP=%d_lambda=%d_mu=%d", lhsP, rhsP, lambda, mu)
+					t.Run(name, func(t *testing.T) {
+						expected := referenceMultiplication(lhs, rhs, lambda, mu)
+
+						plan, err := NewPlan[float64](X, lhsP, rhsP, lambda, mu)
+						if err != nil {
+							t.Fatalf("NewPlan: %v", err)
+						}
+
+						for _, strategy := range strategies {
+							if err := plan.SetStrategy(strategy); err != nil {
+								t.Fatalf("SetStrategy(%v): %v", strategy, err)
+							}
+
+							// Выходная матрица заполнена мусором, чтобы проверить её обнуление
+							out := plan.NewResult()
+							for i := range out.Data {
+								out.Data[i] = 42
+							}
+							if err := plan.Execute(lhs, rhs, out); err != nil {
+								t.Fatalf("Execute(%v): %v", strategy, err)
+							}
+							compareMatrices(t, expected, out)
+
+							for i := range out.Data {
+								out.Data[i] = 42
+							}
+							if err := plan.ParallelExecute(lhs, rhs, out); err != nil {
+								t.Fatalf("ParallelExecute(%v): %v", strategy, err)
+							}
+							compareMatrices(t, expected, out)
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+// TestAutoStrategy тестирует автоматический выбор стратегии
+func TestAutoStrategy(t *testing.T) {
+	tests := []struct {
+		x, lhsP, rhsP, lambda, mu uint32
+		expected                  Strategy
+	}{
+		{x: 3, lhsP: 2, rhsP: 2, lambda: 1, mu: 1, expected: StrategyDirect},
+		{x: 10, lhsP: 4, rhsP: 4, lambda: 0, mu: 2, expected: StrategyGEMM},
+		{x: 10, lhsP: 4, rhsP: 4, lambda: 2, mu: 2, expected: StrategyDirect},
+		{x: 10, lhsP: 4, rhsP: 4, lambda: 3, mu: 0, expected: StrategyGEMM},
+		{x: 4, lhsP: 8, rhsP: 3, lambda: 0, mu: 2, expected: StrategyDirect},
+	}
+
+	for _, tt := range tests {
+		plan, err := NewPlan[uint32](tt.x, tt.lhsP, tt.rhsP, tt.lambda, tt.mu)
+		if err != nil {
+			t.Fatalf("NewPlan: %v", err)
+		}
+		if plan.Strategy() != tt.expected {
+			t.Errorf("Auto strategy for X=%d P=%d,%d lambda=%d mu=%d: got %v, expected %v",
+				tt.x, tt.lhsP, tt.rhsP, tt.lambda, tt.mu, plan.Strategy(), tt.expected)
+		}
+	}
+
+	plan, _ := NewPlan[uint32](3, 2, 2, 1, 1)
+	if err := plan.SetStrategy(Strategy(99)); !errors.Is(err, ErrInvalidStrategy) {
+		t.Errorf("SetStrategy error = %v, expected ErrInvalidStrategy", err)
+	}
+}
+
+// BenchmarkStrategies сравнивает стратегии вычисления плана
+func BenchmarkStrategies(b *testing.B) {
+	configs := []struct {
+		x, p, lambda, mu uint32
+	}{
+		{10, 4, 0, 2},
+		{10, 4, 1, 1},
+		{10, 4, 2, 1},
+		{10, 6, 1, 3},
+		{10, 6, 2, 2},
+		{4, 8, 2, 3},
+	}
+
+	for _, config := range configs {
+		lhs := CreateMatrix[float64](config.x, config.p)
+		for i := range lhs.Data {
+			lhs.Data[i] = float64(i % 5)
+		}
+		rhs := CreateMatrix[float64](config.x, config.p)
+		for i := range rhs.Data {
+			rhs.Data[i] = float64((i + 2) % 5)
+		}
+
+		plan, err := NewPlan[float64](config.x, config.p, config.p, config.lambda, config.mu)
+		if err != nil {
+			b.Fatalf("NewPlan: %v", err)
+		}
+		out := plan.NewResult()
+
+		for _, strategy := range []Strategy{StrategyDirect, StrategyGEMM} {
+			name := fmt.Sprintf("X=%d_P=%d_lambda=%d_mu=%d_%v", config.x, config.p, config.lambda, config.mu, strategy)
+			b.Run(name, func(b *testing.B) {
+				_ = plan.SetStrategy(strategy)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_ = plan.Execute(lhs, rhs, out)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoIdentity возвращается, если (λ,μ)-единица с нужной стороны не существует
+var ErrNoIdentity = errors.New("mdm: identity does not exist")
+
+// Identity строит правую (λ,μ)-единичную матрицу E для матриц размерности X^P:
+// A.Multiplication(lambda, mu, E) == A для любой такой A.
+//
+// E имеет порядок λ+2μ и оси [s, c, m], где s — λ скоттовых индексов,
+// а c и m — по μ индексов: e[s, c, m] = 1 при c == m и 0 иначе.
+// При μ == 0 все элементы E равны 1
+func Identity[T Number](X, P, lambda, mu uint32) (*Matrix[T], error) {
+	sSize, cSize, err := identitySizes(X, P, lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	e, err := NewMatrix[T](X, lambda+2*mu)
+	if err != nil {
+		return nil, err
+	}
+	for s := 0; s < sSize; s++ {
+		base := s * cSize * cSize
+		for c := 0; c < cSize; c++ {
+			e.Data[base+c*cSize+c] = 1
+		}
+	}
+	return e, nil
+}
+
+// LeftIdentity строит левую (λ,μ)-единичную матрицу E для матриц размерности X^P:
+// E.Multiplication(lambda, mu, A) == A для любой такой A.
+//
+// E имеет порядок λ+2μ и оси [l, s, c]: e[l, s, c] = 1 при l == c.
+// Поскольку кэлиевы индексы правого сомножителя следуют за скоттовыми,
+// произведение E·A переставляет их местами и совпадает с A только при
+// λ == 0 или μ == 0; в остальных случаях возвращается ErrNoIdentity
+func LeftIdentity[T Number](X, P, lambda, mu uint32) (*Matrix[T], error) {
+	sSize, cSize, err := identitySizes(X, P, lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	if lambda != 0 && mu != 0 && X > 1 {
+		return nil, fmt.Errorf("%w: left unit for lambda=%d, mu=%d", ErrNoIdentity, lambda, mu)
+	}
+	e, err := NewMatrix[T](X, lambda+2*mu)
+	if err != nil {
+		return nil, err
+	}
+	for c := 0; c < cSize; c++ {
+		base := c * sSize * cSize
+		for s := 0; s < sSize; s++ {
+			e.Data[base+s*cSize+c] = 1
+		}
+	}
+	return e, nil
+}
+
+// identitySizes проверяет параметры единичной матрицы и возвращает
+// число скоттовых (X^λ) и кэлиевых (X^μ) наборов индексов
+func identitySizes(X, P, lambda, mu uint32) (int, int, error) {
+	if uint64(lambda)+uint64(mu) > uint64(P) || uint64(lambda)+2*uint64(mu) > uint64(^uint32(0)) {
+		return 0, 0, fmt.Errorf("%w: lambda=%d, mu=%d, P=%d", ErrInvalidLambdaMu, lambda, mu, P)
+	}
+	sSize, ok := checkedPow(X, lambda)
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %d^%d", ErrSizeOverflow, X, lambda)
+	}
+	cSize, ok := checkedPow(X, mu)
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %d^%d", ErrSizeOverflow, X, mu)
+	}
+	return sSize, cSize, nil
+}
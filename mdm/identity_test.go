@@ -0,0 +1,60 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestIdentity тестирует правую и левую (λ,μ)-единицы для всех допустимых λ и μ
+func TestIdentity(t *testing.T) {
+	for X := uint32(1); X <= 3; X++ {
+		for P := uint32(1); P <= 4; P++ {
+			a := CreateMatrix[int64](X, P)
+			for i := range a.Data {
+				a.Data[i] = int64(i*7%11) - 5
+			}
+
+			for lambda := uint32(0); lambda <= P; lambda++ {
+				for mu := uint32(0); lambda+mu <= P; mu++ {
+					name := fmt.Sprintf("X=%d_P=%d_lambda=%d_mu=%d", X, P, lambda, mu)
+					t.Run(name, func(t *testing.T) {
+						right, err := Identity[int64](X, P, lambda, mu)
+						if err != nil {
+							t.Fatalf("Identity: %v", err)
+						}
+						if right.P != lambda+2*mu {
+							t.Errorf("Identity order: got %d, expected %d", right.P, lambda+2*mu)
+						}
+						compareMatrices(t, a, a.Multiplication(lambda, mu, right))
+
+						left, err := LeftIdentity[int64](X, P, lambda, mu)
+						if lambda != 0 && mu != 0 && X > 1 {
+							if !errors.Is(err, ErrNoIdentity) {
+								t.Errorf("LeftIdentity: expected ErrNoIdentity, got %v", err)
+							}
+							return
+						}
+						if err != nil {
+							t.Fatalf("LeftIdentity: %v", err)
+						}
+						compareMatrices(t, a, left.Multiplication(lambda, mu, a))
+					})
+				}
+			}
+		}
+	}
+}
+
+// TestIdentityErrors тестирует проверку параметров единичной матрицы
+func TestIdentityErrors(t *testing.T) {
+	if _, err := Identity[uint32](3, 2, 2, 1); !errors.Is(err, ErrInvalidLambdaMu) {
+		t.Errorf("Identity with lambda+mu > P: expected ErrInvalidLambdaMu, got %v", err)
+	}
+	if _, err := LeftIdentity[uint32](3, 1, 1, 1); !errors.Is(err, ErrInvalidLambdaMu) {
+		t.Errorf("LeftIdentity with lambda+mu > P: expected ErrInvalidLambdaMu, got %v", err)
+	}
+	if _, err := Identity[uint32](1<<16, 8, 0, 4); !errors.Is(err, ErrSizeOverflow) {
+		t.Errorf("Identity of huge order: expected ErrSizeOverflow, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package mdm
+
+// calculateIndexFromArray переводит многомерный индекс в линейное смещение
+// в смешанной системе счисления с основаниями shape. Вычисления ведутся в int,
+// поэтому матрицы с числом элементов больше 2^32 индексируются без наложения
+func calculateIndexFromArray(arrayIndex []uint32, shape []int) int {
+	var resultIndex int
+	for idx, digit := range arrayIndex {
+		resultIndex = resultIndex*shape[idx] + int(digit)
+	}
+	return resultIndex
+}
+
+// calculateIndexToArray переводит линейное смещение в многомерный индекс формы shape
+func calculateIndexToArray(shape []int, index int) []uint32 {
+	resultVector := make([]uint32, len(shape))
+	fastCalculateIndexToArray(shape, index, resultVector)
+	return resultVector
+}
+
+// fastCalculateIndexToArray переводит линейное смещение в многомерный индекс
+// без выделения памяти; result должен иметь длину len(shape)
+func fastCalculateIndexToArray(shape []int, index int, result []uint32) {
+	temp := index
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = uint32(temp % shape[i])
+		temp /= shape[i]
+	}
+}
+
+func incrementToIndexVector(vec []uint32, lastIndex int, shape []int) {
+	for i := lastIndex; i >= 0; i-- {
+		if int(vec[i]) == shape[i]-1 {
+			vec[i] = 0
+		} else {
+			vec[i]++
+			return
+		}
+	}
+}
+
+func filledZeroVector(vec []uint32, startIdx int, mu uint32) {
+	idxCursor := startIdx
+	for i := uint32(0); i < mu; i++ {
+		vec[idxCursor] = 0
+		if i+1 == mu {
+			break
+		}
+		idxCursor--
+	}
+}
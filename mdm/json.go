@@ -0,0 +1,291 @@
+package mdm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// jsonMatrix — компактная JSON-форма матрицы: кубическая матрица задаётся
+// полями x и p, некубическая — полем shape
+type jsonMatrix struct {
+	X     *uint32           `json:"x,omitempty"`
+	P     *uint32           `json:"p,omitempty"`
+	Shape []int             `json:"shape,omitempty"`
+	Data  []json.RawMessage `json:"data"`
+}
+
+// MarshalJSON реализует json.Marshaler в компактной форме
+// {"x":3,"p":2,"data":[...]}; некубическая матрица записывается как
+// {"shape":[2,3],"data":[...]}. Комплексные элементы записываются строками
+// вида "(1+2i)", NaN и бесконечности дают ошибку
+func (m *Matrix[T]) MarshalJSON() ([]byte, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	var buf []byte
+	if m.IsCubic() {
+		buf = fmt.Appendf(buf, `{"x":%d,"p":%d,"data":[`, m.X, m.P)
+	} else {
+		buf = append(buf, `{"shape":`...)
+		buf = appendJSONInts(buf, m.dims)
+		buf = append(buf, `,"data":[`...)
+	}
+	for i, v := range m.Data {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var err error
+		if buf, err = appendJSONElement(buf, v); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, "]}"...), nil
+}
+
+// MarshalNestedJSON записывает матрицу вложенными массивами глубины P,
+// например [[1,2],[3,4]]; матрица порядка 0 записывается одним числом.
+// Результат читается UnmarshalJSON; для отступов используйте json.Indent
+func (m *Matrix[T]) MarshalNestedJSON() ([]byte, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return appendNested(nil, m.Data, m.shape())
+}
+
+// UnmarshalJSON реализует json.Unmarshaler и принимает обе формы:
+// объект компактной формы и вложенные массивы. В компактной форме число
+// элементов data должно равняться X^P (или произведению shape), иначе
+// возвращается ErrDataLength; порядок P больше 64 и непрямоугольные
+// вложенные массивы дают ErrInvalidShape. Неверные элементы дают ErrInvalidFormat
+func (m *Matrix[T]) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	var r *Matrix[T]
+	var err error
+	if len(b) > 0 && b[0] == '{' {
+		r, err = unmarshalCompact[T](b)
+	} else {
+		r, err = unmarshalNested[T](b)
+	}
+	if err != nil {
+		return err
+	}
+	*m = *r
+	return nil
+}
+
+func unmarshalCompact[T Number](b []byte) (*Matrix[T], error) {
+	var jm jsonMatrix
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&jm); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	var m *Matrix[T]
+	switch {
+	case jm.Shape != nil && jm.X == nil && jm.P == nil:
+		size, err := checkShape(jm.Shape)
+		if err != nil {
+			return nil, err
+		}
+		if len(jm.Data) != size {
+			return nil, fmt.Errorf("%w: got %d, want %d for shape %v", ErrDataLength, len(jm.Data), size, jm.Shape)
+		}
+		m = fromShape(jm.Shape, make([]T, size))
+	case jm.Shape == nil && jm.X != nil && jm.P != nil:
+		size, err := checkCubic(*jm.X, *jm.P)
+		if err != nil {
+			return nil, err
+		}
+		if len(jm.Data) != size {
+			return nil, fmt.Errorf("%w: got %d, want %d^%d = %d", ErrDataLength, len(jm.Data), *jm.X, *jm.P, size)
+		}
+		m = &Matrix[T]{X: *jm.X, P: *jm.P, Data: make([]T, size)}
+	default:
+		return nil, fmt.Errorf("%w: matrix needs either x and p or shape", ErrInvalidFormat)
+	}
+
+	for i, raw := range jm.Data {
+		v, err := parseJSONElement[T](raw)
+		if err != nil {
+			return nil, fmt.Errorf("data[%d]: %w", i, err)
+		}
+		m.Data[i] = v
+	}
+	return m, nil
+}
+
+func unmarshalNested[T Number](b []byte) (*Matrix[T], error) {
+	var tree any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	// Форма определяется по первым элементам каждого уровня
+	var shape []int
+	for node := tree; ; {
+		list, ok := node.([]any)
+		if !ok {
+			break
+		}
+		shape = append(shape, len(list))
+		if len(list) == 0 {
+			break
+		}
+		node = list[0]
+	}
+	size, err := checkShape(shape)
+	if err != nil {
+		return nil, err
+	}
+	m := fromShape(shape, make([]T, 0, size))
+	if err := flattenNested(tree, shape, &m.Data); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// flattenNested дописывает элементы узла с ожидаемой формой shape в data
+func flattenNested[T Number](node any, shape []int, data *[]T) error {
+	list, isList := node.([]any)
+	if len(shape) == 0 {
+		if isList {
+			return fmt.Errorf("%w: ragged nested array", ErrInvalidShape)
+		}
+		var raw []byte
+		switch v := node.(type) {
+		case json.Number:
+			raw = []byte(v)
+		case string:
+			raw = strconv.AppendQuote(nil, v)
+		default:
+			return fmt.Errorf("%w: element %v", ErrInvalidFormat, node)
+		}
+		v, err := parseJSONElement[T](raw)
+		if err != nil {
+			return err
+		}
+		*data = append(*data, v)
+		return nil
+	}
+	if !isList || len(list) != shape[0] {
+		return fmt.Errorf("%w: ragged nested array", ErrInvalidShape)
+	}
+	for _, child := range list {
+		if err := flattenNested(child, shape[1:], data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendNested дописывает элементы data формы shape вложенными массивами
+func appendNested[T Number](buf []byte, data []T, shape []int) ([]byte, error) {
+	if len(shape) == 0 {
+		return appendJSONElement(buf, data[0])
+	}
+	buf = append(buf, '[')
+	stride := 0
+	if shape[0] > 0 {
+		stride = len(data) / shape[0]
+	}
+	for i := range shape[0] {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var err error
+		if buf, err = appendNested(buf, data[i*stride:(i+1)*stride], shape[1:]); err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, ']'), nil
+}
+
+func appendJSONInts(buf []byte, values []int) []byte {
+	buf = append(buf, '[')
+	for i, n := range values {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendInt(buf, int64(n), 10)
+	}
+	return append(buf, ']')
+}
+
+// appendJSONElement дописывает элемент числом JSON, комплексный — строкой
+func appendJSONElement[T Number](buf []byte, v T) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%w: %v is not representable in JSON", ErrInvalidFormat, f)
+		}
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.AppendQuote(buf, formatElement(v)), nil
+	}
+	return append(buf, formatElement(v)...), nil
+}
+
+// parseJSONElement разбирает элемент JSON: число или, для комплексных
+// типов, также строку с комплексным числом
+func parseJSONElement[T Number](raw []byte) (T, error) {
+	s := string(raw)
+	if kind := reflect.TypeFor[T]().Kind(); kind == reflect.Complex64 || kind == reflect.Complex128 {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			s = unquoted
+		}
+	}
+	return parseElement[T](s)
+}
+
+// formatElement записывает элемент в десятичной форме без потери точности;
+// комплексные числа записываются как в strconv.FormatComplex
+func formatElement[T Number](v T) string {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits())
+	default:
+		return strconv.FormatComplex(rv.Complex(), 'g', -1, rv.Type().Bits())
+	}
+}
+
+// parseElement разбирает элемент, записанный formatElement.
+// Ошибки сопоставляются с ErrInvalidFormat
+func parseElement[T Number](s string) (T, error) {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	var err error
+	switch rv.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, rv.Type().Bits())
+		rv.SetInt(n)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, rv.Type().Bits())
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, rv.Type().Bits())
+		rv.SetFloat(f)
+	default:
+		var c complex128
+		c, err = strconv.ParseComplex(s, rv.Type().Bits())
+		rv.SetComplex(c)
+	}
+	if err != nil {
+		return v, fmt.Errorf("%w: element %q for %v", ErrInvalidFormat, s, rv.Type())
+	}
+	return v, nil
+}
@@ -0,0 +1,118 @@
+package mdm
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestJSONCompact тестирует компактную JSON-форму
+func TestJSONCompact(t *testing.T) {
+	m := CreateMatrix[int64](2, 2)
+	copy(m.Data, []int64{1, -2, 3, 4})
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if expected := `{"x":2,"p":2,"data":[1,-2,3,4]}`; string(b) != expected {
+		t.Errorf("Got %s, expected %s", b, expected)
+	}
+	var got Matrix[int64]
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	compareMatrices(t, m, &got)
+
+	shaped := newSequence(t, 2, 3)
+	b, _ = json.Marshal(shaped)
+	if expected := `{"shape":[2,3],"data":[0,1,2,3,4,5]}`; string(b) != expected {
+		t.Errorf("Got %s, expected %s", b, expected)
+	}
+	var gotShaped Matrix[int64]
+	if err := json.Unmarshal(b, &gotShaped); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	compareMatrices(t, shaped, &gotShaped)
+
+	c := CreateMatrix[complex128](2, 1)
+	copy(c.Data, []complex128{1 + 2i, -0.5i})
+	b, _ = json.Marshal(c)
+	if expected := `{"x":2,"p":1,"data":["(1+2i)","(0-0.5i)"]}`; string(b) != expected {
+		t.Errorf("Got %s, expected %s", b, expected)
+	}
+	var gotComplex Matrix[complex128]
+	if err := json.Unmarshal(b, &gotComplex); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	compareMatrices(t, c, &gotComplex)
+}
+
+// TestJSONNested тестирует форму вложенных массивов
+func TestJSONNested(t *testing.T) {
+	m := newSequence(t, 2, 2, 3)
+	b, err := m.MarshalNestedJSON()
+	if err != nil {
+		t.Fatalf("MarshalNestedJSON: %v", err)
+	}
+	if expected := `[[[0,1,2],[3,4,5]],[[6,7,8],[9,10,11]]]`; string(b) != expected {
+		t.Errorf("Got %s, expected %s", b, expected)
+	}
+	var got Matrix[int64]
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	compareMatrices(t, m, &got)
+
+	var cubic Matrix[float32]
+	if err := json.Unmarshal([]byte(" [[1.5, 2], [3, 4]] "), &cubic); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if cubic.X != 2 || cubic.P != 2 || !slices.Equal(cubic.Data, []float32{1.5, 2, 3, 4}) {
+		t.Errorf("Got %+v", cubic)
+	}
+
+	var scalar Matrix[uint8]
+	if err := json.Unmarshal([]byte("7"), &scalar); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if scalar.P != 0 || !slices.Equal(scalar.Data, []uint8{7}) {
+		t.Errorf("Got %+v", scalar)
+	}
+}
+
+// TestJSONErrors тестирует проверку JSON
+func TestJSONErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		target error
+	}{
+		{`{"x":2,"p":2,"data":[1,2,3]}`, ErrDataLength},
+		{`{"shape":[2,3],"data":[1,2,3]}`, ErrDataLength},
+		{`{"x":2,"data":[1,2]}`, ErrInvalidFormat},
+		{`{"x":2,"p":1,"shape":[2],"data":[1,2]}`, ErrInvalidFormat},
+		{`{"x":2,"p":1,"data":[1,2],"extra":1}`, ErrInvalidFormat},
+		{`{"x":2,"p":1,"data":[1.5,2]}`, ErrInvalidFormat},
+		{`{"x":2,"p":1,"data":[300,2]}`, ErrInvalidFormat},
+		{`{"x":4294967295,"p":4,"data":[]}`, ErrSizeOverflow},
+		{`{"x":1,"p":400000000,"data":[1]}`, ErrInvalidShape},
+		{`{"x":0,"p":4294967295,"data":[]}`, ErrInvalidShape},
+		{`[[1,2],[3]]`, ErrInvalidShape},
+		{`[[1,2],3]`, ErrInvalidShape},
+		{`[1,[2]]`, ErrInvalidShape},
+		{`[true]`, ErrInvalidFormat},
+		{`[1,`, ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		var m Matrix[int8]
+		if err := m.UnmarshalJSON([]byte(tt.input)); !errors.Is(err, tt.target) {
+			t.Errorf("%s: expected %v, got %v", tt.input, tt.target, err)
+		}
+	}
+
+	nan := CreateMatrix[float64](1, 1)
+	nan.Data[0] = nan.Data[0] / nan.Data[0]
+	if _, err := json.Marshal(nan); err == nil {
+		t.Error("Expected error for NaN")
+	}
+}
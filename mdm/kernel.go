@@ -0,0 +1,236 @@
+package mdm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// cellCursor обходит слагаемые одной ячейки результата (λ,μ)-умножения.
+// Используется ядрами с нестандартным накоплением суммы
+type cellCursor[T Number] struct {
+	lhs, rhs   *Matrix[T]
+	lambda, mu uint32
+
+	lhsShape, rhsShape, resultShape []int
+
+	// Число слагаемых в ячейке — произведение длин кэлиевых осей
+	terms int
+
+	lastLHSIndex int
+	lastRHSIndex int
+
+	indexLHS          []uint32
+	indexRHS          []uint32
+	indexMatrixResult []uint32
+}
+
+// newCellCursor создаёт курсор для проверенных операндов и формы результата
+func newCellCursor[T Number](lhs, rhs *Matrix[T], lambda, mu uint32, resultShape []int) *cellCursor[T] {
+	rhsShape := rhs.shape()
+	terms, _ := shapeSize(rhsShape[lambda : lambda+mu])
+	return &cellCursor[T]{
+		lhs:               lhs,
+		rhs:               rhs,
+		lambda:            lambda,
+		mu:                mu,
+		lhsShape:          lhs.shape(),
+		rhsShape:          rhsShape,
+		resultShape:       resultShape,
+		terms:             terms,
+		lastLHSIndex:      int(lhs.P) - 1,
+		lastRHSIndex:      int(lambda+mu) - 1,
+		indexLHS:          make([]uint32, lhs.P),
+		indexRHS:          make([]uint32, rhs.P),
+		indexMatrixResult: make([]uint32, len(resultShape)),
+	}
+}
+
+// seek устанавливает курсор на первое слагаемое ячейки idx
+func (c *cellCursor[T]) seek(idx int) {
+	resetSlice(c.indexLHS)
+	resetSlice(c.indexRHS)
+	fastCalculateIndexToArray(c.resultShape, idx, c.indexMatrixResult)
+	updateIndexMappings(c.indexMatrixResult, c.indexLHS, c.indexRHS, c.lhs.P, c.rhs.P, c.lambda, c.mu)
+}
+
+// pair возвращает сомножители текущего слагаемого
+func (c *cellCursor[T]) pair() (T, T) {
+	return c.lhs.Data[calculateIndexFromArray(c.indexLHS, c.lhsShape)],
+		c.rhs.Data[calculateIndexFromArray(c.indexRHS, c.rhsShape)]
+}
+
+// next переходит к следующему слагаемому ячейки
+func (c *cellCursor[T]) next() {
+	if c.mu == 0 {
+		return
+	}
+	incrementToIndexVector(c.indexLHS, c.lastLHSIndex, c.lhsShape)
+	incrementToIndexVector(c.indexRHS, c.lastRHSIndex, c.rhsShape)
+}
+
+// runChunks обрабатывает диапазон [0, size) параллельно с параметрами по умолчанию
+func runChunks(size int, work func(start, end int)) {
+	_ = (*Options)(nil).schedule(context.Background(), cellWorkload(size, 0, work), true)
+}
+
+// cancelWork — примерное число умножений в одной порции работы
+// при автоматическом выборе ChunkSize; между порциями проверяется отмена
+const cancelWork = 1 << 16
+
+// chunksPerWorker — число порций на исполнителя при автоматическом выборе
+// ChunkSize; запас порций позволяет выровнять нагрузку перехватом работы
+const chunksPerWorker = 4
+
+// workload описывает диапазон единиц работы [0, units), обрабатываемый порциями
+type workload struct {
+	units int
+	// Число ячеек результата во всём диапазоне и в одной единице
+	cells, unitCells int
+	// Примерная стоимость единицы в умножениях; 0 — неизвестно
+	unitCost int
+	// run обрабатывает единицы [start, end) и возвращает число вычисленных ячеек
+	run func(start, end int) int
+}
+
+// cellWorkload описывает работу, единица которой — одна ячейка результата
+func cellWorkload(size, cellCost int, work func(start, end int)) workload {
+	return workload{
+		units:     size,
+		cells:     size,
+		unitCells: 1,
+		unitCost:  cellCost,
+		run: func(start, end int) int {
+			work(start, end)
+			return end - start
+		},
+	}
+}
+
+// schedule обрабатывает единицы работы w порциями: параллельно или, при
+// parallel == false, в текущей горутине. Каждый исполнитель начинает со
+// своей непрерывной части и берёт из неё порции с начала; закончив, он
+// перехватывает вторую половину оставшейся части у другого исполнителя.
+// Перед каждой порцией проверяется ctx; если хотя бы одна порция пропущена,
+// возвращается ctx.Err(). Если хотя бы один исполнитель не удалось
+// запустить, возвращается первая ошибка запуска. К моменту возврата все
+// исполнители завершены
+func (o *Options) schedule(ctx context.Context, w workload, parallel bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if w.units <= 0 {
+		return nil
+	}
+	workers := 1
+	if parallel {
+		workers = o.workers()
+	}
+	chunk := o.chunk(w.units, workers, w.unitCells, w.unitCost)
+	workers = min(workers, (w.units+chunk-1)/chunk)
+
+	ranges := make([]workRange, workers)
+	part := (w.units + workers - 1) / workers
+	for i := range ranges {
+		ranges[i].next = min(i*part, w.units)
+		ranges[i].end = min((i+1)*part, w.units)
+	}
+
+	progress := o.newProgress(w.cells)
+	var wg sync.WaitGroup
+	var cancelled atomic.Bool
+	worker := func(self int) {
+		defer wg.Done()
+		own := &ranges[self]
+		for {
+			start, end, ok := own.take(chunk)
+			if !ok {
+				if start, end, ok = steal(ranges, self); !ok {
+					return
+				}
+				own.reset(start, end)
+				continue
+			}
+			if ctx.Err() != nil {
+				cancelled.Store(true)
+				return
+			}
+			progress.add(w.run(start, end))
+		}
+	}
+
+	var spawnErr error
+	for i := range workers {
+		wg.Add(1)
+		if !parallel {
+			worker(i)
+			continue
+		}
+		if err := o.spawn(ctx, func() { worker(i) }); err != nil {
+			// Незапущенные части забирают уже работающие исполнители, но
+			// первая ошибка запуска любого из них всё равно возвращается:
+			// вызывающий должен узнать о закрытии пула или отмене
+			wg.Done()
+			if spawnErr == nil {
+				spawnErr = err
+			}
+		}
+	}
+	wg.Wait()
+	switch {
+	case spawnErr != nil:
+		return spawnErr
+	case cancelled.Load():
+		return ctx.Err()
+	}
+	progress.finish()
+	return nil
+}
+
+// workRange — непрерывная часть единиц работы [next, end) одного исполнителя
+type workRange struct {
+	mu        sync.Mutex
+	next, end int
+}
+
+// take забирает порцию из начала части
+func (r *workRange) take(chunk int) (int, int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.next >= r.end {
+		return 0, 0, false
+	}
+	start := r.next
+	r.next = min(start+chunk, r.end)
+	return start, r.next, true
+}
+
+// split отдаёт вторую половину оставшейся части
+func (r *workRange) split() (int, int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.end - r.next
+	if n <= 0 {
+		return 0, 0, false
+	}
+	start, end := r.next+n/2, r.end
+	r.end = start
+	return start, end, true
+}
+
+// reset заменяет опустевшую часть перехваченной
+func (r *workRange) reset(start, end int) {
+	r.mu.Lock()
+	r.next, r.end = start, end
+	r.mu.Unlock()
+}
+
+// steal перехватывает работу у первого непустого исполнителя после self
+func steal(ranges []workRange, self int) (int, int, bool) {
+	for i := 1; i < len(ranges); i++ {
+		if start, end, ok := ranges[(self+i)%len(ranges)].split(); ok {
+			return start, end, true
+		}
+	}
+	return 0, 0, false
+}
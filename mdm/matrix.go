@@ -0,0 +1,274 @@
+package mdm
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Number объединяет типы элементов, для которых определено (λ,μ)-умножение
+type Number interface {
+	~int8 | ~int16 | ~int32 | ~int64 |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 |
+		~complex64 | ~complex128
+}
+
+// Matrix — многомерная матрица порядка P с элементами типа T.
+// Кубическая матрица имеет длину X по каждой оси; у некубической матрицы,
+// созданной через NewShaped, X == 0, а длины осей возвращает Shape
+type Matrix[T Number] struct {
+	X    uint32
+	P    uint32
+	Data []T
+
+	// Длины осей некубической матрицы; nil для кубической
+	dims []int
+}
+
+// CreateMatrix создаёт нулевую матрицу размерности X^P.
+// Паникует, если X^P не помещается в int; для обработки ошибки используйте NewMatrix
+func CreateMatrix[T Number](X, P uint32) *Matrix[T] {
+	m, err := NewMatrix[T](X, P)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// NewMatrix создаёт нулевую матрицу размерности X^P.
+// Возвращает ErrSizeOverflow, если число элементов не помещается в int
+func NewMatrix[T Number](X, P uint32) (*Matrix[T], error) {
+	size, ok := checkedPow(X, P)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d^%d", ErrSizeOverflow, X, P)
+	}
+	return &Matrix[T]{X: X, P: P, Data: make([]T, size)}, nil
+}
+
+// Multiplication выполняет матричное умножение с заданными параметрами lambda и mu.
+// Паникует при некорректных операндах; для обработки ошибок используйте MultiplyChecked.
+// Отмену и отчёты о ходе выполнения поддерживает только MultiplyWith.
+// Для вычислений по модулю p используйте MultiplySemiring с кольцом NewGF
+func (m *Matrix[T]) Multiplication(lambda, mu uint32, other *Matrix[T]) *Matrix[T] {
+	result, err := m.MultiplyChecked(lambda, mu, other)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// MultiplyChecked выполняет матричное умножение с проверкой операндов.
+// Ошибки сопоставляются с ErrNilMatrix, ErrDimensionMismatch, ErrInvalidLambdaMu,
+// ErrDataLength и ErrSizeOverflow через errors.Is
+func (m *Matrix[T]) MultiplyChecked(lambda, mu uint32, other *Matrix[T]) (*Matrix[T], error) {
+	return m.multiplyPlanned(nil, lambda, mu, other, false)
+}
+
+// multiplyPlanned проверяет операнды и выполняет умножение по плану
+// с автоматически выбранной стратегией; nil sr означает обычное полукольцо (+, ×)
+func (m *Matrix[T]) multiplyPlanned(sr Semiring[T], lambda, mu uint32, other *Matrix[T], parallel bool) (*Matrix[T], error) {
+	if _, _, err := validateOperands(m, other, lambda, mu); err != nil {
+		return nil, err
+	}
+	plan, err := NewShapedPlan[T](m.shape(), other.shape(), lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	matrixResult := plan.NewResult()
+	plan.runSemiring(sr, m, other, matrixResult, parallel)
+	return matrixResult, nil
+}
+
+// multiplication выполняет последовательное умножение проверенных операндов
+// поэлементным обходом индексов (стратегия StrategyOdometer)
+func (m *Matrix[T]) multiplication(lambda, mu uint32, other, matrixResult *Matrix[T]) {
+	size := len(matrixResult.Data)
+	lhsShape, rhsShape, resultShape := m.shape(), other.shape(), matrixResult.shape()
+
+	// Предварительные вычисления
+	muPower, _ := shapeSize(rhsShape[lambda : lambda+mu])
+	lastLHSIndex := int(m.P - 1)
+	lastRHSIndex := int(lambda + mu - 1)
+
+	// Инициализация индексных массивов
+	indexLHS := make([]uint32, m.P)
+	indexRHS := make([]uint32, other.P)
+	indexMatrixResult := make([]uint32, matrixResult.P)
+
+	for idx := range matrixResult.Data {
+		var tempValue T
+
+		if mu > 0 {
+			// Многократное суммирование для mu > 0
+			for sumIdx := 0; sumIdx < muPower; sumIdx++ {
+				tempValue += m.Data[calculateIndexFromArray(indexLHS, lhsShape)] *
+					other.Data[calculateIndexFromArray(indexRHS, rhsShape)]
+
+				if sumIdx+1 < muPower {
+					incrementToIndexVector(indexLHS, lastLHSIndex, lhsShape)
+					incrementToIndexVector(indexRHS, lastRHSIndex, rhsShape)
+				}
+			}
+		} else {
+			// Единичное умножение для mu = 0
+			tempValue += m.Data[calculateIndexFromArray(indexLHS, lhsShape)] *
+				other.Data[calculateIndexFromArray(indexRHS, rhsShape)]
+		}
+
+		matrixResult.Data[idx] = tempValue
+
+		// Сброс индексов для следующей итерации
+		filledZeroVector(indexLHS, lastLHSIndex, mu)
+		filledZeroVector(indexRHS, lastRHSIndex, mu)
+
+		// Обновление индексов результата
+		if idx+1 < size {
+			incrementIndexVector(indexMatrixResult, resultShape)
+			updateIndexMappings(indexMatrixResult, indexLHS, indexRHS, m.P, other.P, lambda, mu)
+		}
+	}
+}
+
+// ParallelMultiplication выполняет параллельное матричное умножение.
+// Паникует при некорректных операндах; для обработки ошибок используйте ParallelMultiplyChecked.
+// Отмену поддерживают ParallelMultiplyContext и ParallelMultiplyWith, отчёты
+// о ходе выполнения — только ParallelMultiplyWith. Для вычислений по модулю p
+// используйте ParallelMultiplySemiring с кольцом NewGF
+func (m *Matrix[T]) ParallelMultiplication(lambda, mu uint32, other *Matrix[T]) *Matrix[T] {
+	result, err := m.ParallelMultiplyChecked(lambda, mu, other)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// ParallelMultiplyChecked выполняет параллельное матричное умножение с проверкой операндов
+func (m *Matrix[T]) ParallelMultiplyChecked(lambda, mu uint32, other *Matrix[T]) (*Matrix[T], error) {
+	return m.multiplyPlanned(nil, lambda, mu, other, true)
+}
+
+// parallelMultiplication выполняет параллельное умножение проверенных операндов
+// поэлементным обходом индексов (стратегия StrategyOdometer)
+func (m *Matrix[T]) parallelMultiplication(lambda, mu uint32, other, matrixResult *Matrix[T]) {
+	runChunks(len(matrixResult.Data), m.odometerRange(lambda, mu, other, matrixResult))
+}
+
+// odometerRange возвращает функцию, вычисляющую ячейки результата [start, end)
+// поэлементным обходом индексов; её можно вызывать из нескольких горутин
+func (m *Matrix[T]) odometerRange(lambda, mu uint32, other, matrixResult *Matrix[T]) func(start, end int) {
+	resultP := matrixResult.P
+	lhsShape, rhsShape, resultShape := m.shape(), other.shape(), matrixResult.shape()
+
+	// Используем пул для переиспользования массивов
+	indexPool := &sync.Pool{
+		New: func() interface{} {
+			return &IndexBundle{
+				lhs: make([]uint32, m.P),
+				rhs: make([]uint32, other.P),
+				res: make([]uint32, resultP),
+			}
+		},
+	}
+
+	return func(start, end int) {
+		muPower, _ := shapeSize(rhsShape[lambda : lambda+mu])
+		lastLHSIndex := int(m.P - 1)
+		lastRHSIndex := int(lambda + mu - 1)
+
+		// Получаем bundle из пула
+		bundle := indexPool.Get().(*IndexBundle)
+		defer indexPool.Put(bundle)
+
+		indexLHS := bundle.lhs
+		indexRHS := bundle.rhs
+		indexMatrixResult := bundle.res
+
+		for idx := start; idx < end; idx++ {
+			// Переиспользуем массивы, сбрасывая их перед использованием
+			resetSlice(indexLHS)
+			resetSlice(indexRHS)
+			resetSlice(indexMatrixResult)
+
+			// Вычисляем индекс
+			fastCalculateIndexToArray(resultShape, idx, indexMatrixResult)
+
+			// Обновляем маппинги
+			updateIndexMappings(indexMatrixResult, indexLHS, indexRHS, m.P, other.P, lambda, mu)
+
+			var tempValue T
+
+			if mu > 0 {
+				for sumIdx := 0; sumIdx < muPower; sumIdx++ {
+					tempValue += m.Data[calculateIndexFromArray(indexLHS, lhsShape)] *
+						other.Data[calculateIndexFromArray(indexRHS, rhsShape)]
+
+					if sumIdx+1 < muPower {
+						incrementToIndexVector(indexLHS, lastLHSIndex, lhsShape)
+						incrementToIndexVector(indexRHS, lastRHSIndex, rhsShape)
+					}
+				}
+			} else {
+				tempValue += m.Data[calculateIndexFromArray(indexLHS, lhsShape)] *
+					other.Data[calculateIndexFromArray(indexRHS, rhsShape)]
+			}
+
+			matrixResult.Data[idx] = tempValue
+		}
+	}
+}
+
+// IndexBundle для группировки индексных массивов
+type IndexBundle struct {
+	lhs []uint32
+	rhs []uint32
+	res []uint32
+}
+
+// resetSlice обнуляет слайс
+func resetSlice(slice []uint32) {
+	for i := range slice {
+		slice[i] = 0
+	}
+}
+
+// updateIndexMappings обновляет сопоставления индексов между матрицами
+func updateIndexMappings(indexMatrixResult, indexLHS, indexRHS []uint32,
+	lhsP, rhsP uint32, lambda, mu uint32) {
+	// Сопоставление для левой матрицы
+	lhsSyncIdx := 0
+	for i := 0; i < int(lhsP-mu); i++ {
+		indexLHS[lhsSyncIdx] = indexMatrixResult[lhsSyncIdx]
+		lhsSyncIdx++
+	}
+
+	// Сопоставление для правой матрицы (левая часть): скоттовы индексы
+	// результата следуют сразу за свободными индексами левой матрицы
+	rhsLeftSyncIdx := int(lhsP - lambda - mu)
+	rhsLeftIdx := 0
+	for i := 0; i < int(lambda); i++ {
+		indexRHS[rhsLeftIdx] = indexMatrixResult[rhsLeftSyncIdx]
+		rhsLeftIdx++
+		rhsLeftSyncIdx++
+	}
+
+	// Сопоставление для правой матрицы (правая часть): свободные индексы m
+	rhsRightSyncIdx := len(indexMatrixResult) - 1
+	rhsRightIdx := int(rhsP - 1)
+	for i := 0; i < int(rhsP-lambda-mu); i++ {
+		indexRHS[rhsRightIdx] = indexMatrixResult[rhsRightSyncIdx]
+		rhsRightIdx--
+		rhsRightSyncIdx--
+	}
+}
+
+// incrementIndexVector увеличивает вектор индексов на 1 в смешанной системе счисления
+func incrementIndexVector[I uint32 | int](vec []I, shape []int) {
+	for i := len(vec) - 1; i >= 0; i-- {
+		if int(vec[i]) == shape[i]-1 {
+			vec[i] = 0
+		} else {
+			vec[i]++
+			return
+		}
+	}
+}
@@ -1,8 +1,10 @@
-package main
+package mdm
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"testing"
 )
 
@@ -30,7 +32,7 @@ func TestCalculateIndexFromArray(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calculateIndexFromArray(tt.array, tt.x)
+			result := calculateIndexFromArray(tt.array, cubicShape(tt.x, uint32(len(tt.array))))
 			if result != tt.expected {
 				t.Errorf("calculateIndexFromArray(%v, %d) = %d, expected %d",
 					tt.array, tt.x, result, tt.expected)
@@ -66,7 +68,7 @@ func TestCalculateIndexToArray(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calculateIndexToArray(tt.p, tt.x, tt.index)
+			result := calculateIndexToArray(cubicShape(tt.x, tt.p), tt.index)
 			if len(result) != len(tt.expected) {
 				t.Errorf("Length mismatch: got %d, expected %d", len(result), len(tt.expected))
 				return
@@ -95,10 +97,10 @@ func TestIndexConversionConsistency(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("p=%d_x=%d_index=%d", tc.p, tc.x, tc.index), func(t *testing.T) {
 			// Преобразуем индекс в массив
-			array := calculateIndexToArray(tc.p, tc.x, tc.index)
+			array := calculateIndexToArray(cubicShape(tc.x, tc.p), tc.index)
 
 			// Преобразуем массив обратно в индекс
-			calculatedIndex := calculateIndexFromArray(array, tc.x)
+			calculatedIndex := calculateIndexFromArray(array, cubicShape(tc.x, tc.p))
 
 			// Должны получить исходный индекс
 			if calculatedIndex != tc.index {
@@ -109,13 +111,57 @@ func TestIndexConversionConsistency(t *testing.T) {
 	}
 }
 
+// TestLargeIndexArithmetic тестирует индексацию матриц с числом элементов больше 2^32
+func TestLargeIndexArithmetic(t *testing.T) {
+	t.Run("X=16 P=8 last element", func(t *testing.T) {
+		array := []uint32{15, 15, 15, 15, 15, 15, 15, 15}
+		expected := 1<<32 - 1
+		if result := calculateIndexFromArray(array, cubicShape(16, 8)); result != expected {
+			t.Errorf("calculateIndexFromArray(%v, 16) = %d, expected %d", array, result, expected)
+		}
+	})
+
+	t.Run("X=16 P=9 round trip beyond 2^32", func(t *testing.T) {
+		for _, index := range []int{1 << 32, 1<<33 + 12345, 1<<36 - 1} {
+			array := calculateIndexToArray(cubicShape(16, 9), index)
+			if result := calculateIndexFromArray(array, cubicShape(16, 9)); result != index {
+				t.Errorf("Index conversion inconsistent: original=%d, calculated=%d, array=%v",
+					index, result, array)
+			}
+
+			fast := make([]uint32, 9)
+			fastCalculateIndexToArray(cubicShape(16, 9), index, fast)
+			for i := range fast {
+				if fast[i] != array[i] {
+					t.Errorf("fastCalculateIndexToArray mismatch at %d: got %v, expected %v", index, fast, array)
+					break
+				}
+			}
+		}
+	})
+
+	t.Run("NewMatrix size overflow", func(t *testing.T) {
+		if _, err := NewMatrix[uint8](16, 16); !errors.Is(err, ErrSizeOverflow) {
+			t.Errorf("NewMatrix(16, 16) error = %v, expected ErrSizeOverflow", err)
+		}
+
+		m, err := NewMatrix[uint8](16, 3)
+		if err != nil {
+			t.Fatalf("NewMatrix(16, 3): %v", err)
+		}
+		if len(m.Data) != 4096 {
+			t.Errorf("NewMatrix(16, 3) size: got %d, expected 4096", len(m.Data))
+		}
+	})
+}
+
 // TestMatrixMultiplicationConsistency тестирует, что последовательная и параллельная версии дают одинаковый результат
 func TestMatrixMultiplicationConsistency(t *testing.T) {
 	// Создаем тестовые матрицы
-	lhs := CreateMatrix(3, 2)
+	lhs := CreateMatrix[uint32](3, 2)
 	lhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
-	rhs := CreateMatrix(3, 2)
+	rhs := CreateMatrix[uint32](3, 2)
 	rhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
 	// Тестируем различные параметры
@@ -147,11 +193,11 @@ func TestMatrixMultiplicationConsistency(t *testing.T) {
 // TestMatrixProperties тестирует свойства матричных операций
 func TestMatrixProperties(t *testing.T) {
 	// Создаем тестовую матрицу
-	matrix := CreateMatrix(2, 2)
+	matrix := CreateMatrix[uint32](2, 2)
 	matrix.Data = []uint32{1, 2, 3, 4}
 
 	// Создаем нулевую матрицу
-	zeroMatrix := CreateMatrix(2, 2)
+	zeroMatrix := CreateMatrix[uint32](2, 2)
 	matrix.Data = []uint32{0, 0, 0, 0}
 
 	t.Run("Multiplication with zero matrix", func(t *testing.T) {
@@ -180,10 +226,10 @@ func TestMatrixProperties(t *testing.T) {
 // TestEdgeCases тестирует граничные случаи
 func TestEdgeCases(t *testing.T) {
 	t.Run("Single element matrices", func(t *testing.T) {
-		a := CreateMatrix(2, 1)
+		a := CreateMatrix[uint32](2, 1)
 		a.Data = []uint32{5, 3}
 
-		b := CreateMatrix(2, 1)
+		b := CreateMatrix[uint32](2, 1)
 		b.Data = []uint32{3, 5}
 
 		result := a.Multiplication(0, 0, b)
@@ -197,10 +243,10 @@ func TestEdgeCases(t *testing.T) {
 	})
 
 	t.Run("Different sizes", func(t *testing.T) {
-		a := CreateMatrix(3, 2)
+		a := CreateMatrix[uint32](3, 2)
 		a.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
-		b := CreateMatrix(3, 1)
+		b := CreateMatrix[uint32](3, 1)
 		b.Data = []uint32{1, 2, 3}
 
 		// Это должно работать, если логика умножения поддерживает разные размерности
@@ -228,8 +274,8 @@ func TestResultMatrixDimensions(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("pA=%d_pB=%d_lambda=%d_mu=%d", tc.pA, tc.pB, tc.lambda, tc.mu), func(t *testing.T) {
-			a := CreateMatrix(2, tc.pA)
-			b := CreateMatrix(2, tc.pB)
+			a := CreateMatrix[uint32](2, tc.pA)
+			b := CreateMatrix[uint32](2, tc.pB)
 
 			result := a.Multiplication(tc.lambda, tc.mu, b)
 
@@ -250,10 +296,10 @@ func TestKnownMultiplicaton(t *testing.T) {
 	t.Helper()
 
 	t.Run("3*3 X 3*3 (1,1)", func(t *testing.T) {
-		lhs := CreateMatrix(3, 2)
+		lhs := CreateMatrix[uint32](3, 2)
 		lhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
-		rhs := CreateMatrix(3, 2)
+		rhs := CreateMatrix[uint32](3, 2)
 		rhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
 		res := []uint32{14, 77, 194}
@@ -276,10 +322,10 @@ func TestKnownMultiplicaton(t *testing.T) {
 	})
 
 	t.Run("3*3 X 3*1 (0,1)", func(t *testing.T) {
-		lhs := CreateMatrix(3, 2)
+		lhs := CreateMatrix[uint32](3, 2)
 		lhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
-		rhs := CreateMatrix(3, 1)
+		rhs := CreateMatrix[uint32](3, 1)
 		rhs.Data = []uint32{1, 2, 3}
 
 		res := []uint32{14, 32, 50}
@@ -302,10 +348,10 @@ func TestKnownMultiplicaton(t *testing.T) {
 	})
 
 	t.Run("3*3 X 3*3 (0,1)", func(t *testing.T) {
-		lhs := CreateMatrix(3, 2)
+		lhs := CreateMatrix[uint32](3, 2)
 		lhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
-		rhs := CreateMatrix(3, 2)
+		rhs := CreateMatrix[uint32](3, 2)
 		rhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
 		res := []uint32{30, 36, 42, 66, 81, 96, 102, 126, 150}
@@ -328,10 +374,10 @@ func TestKnownMultiplicaton(t *testing.T) {
 	})
 
 	t.Run("3*3 X 3*3 (1,0)", func(t *testing.T) {
-		lhs := CreateMatrix(3, 2)
+		lhs := CreateMatrix[uint32](3, 2)
 		lhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
-		rhs := CreateMatrix(3, 2)
+		rhs := CreateMatrix[uint32](3, 2)
 		rhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
 		res := []uint32{1, 2, 3, 8, 10, 12, 21, 24, 27, 4, 8, 12, 20, 25, 30, 42, 48, 54, 7, 14, 21, 32, 40, 48, 63, 72, 81}
@@ -354,17 +400,86 @@ func TestKnownMultiplicaton(t *testing.T) {
 	})
 }
 
+// TestGenericElementTypes тестирует умножение для различных типов элементов
+func TestGenericElementTypes(t *testing.T) {
+	t.Run("int32 with negative values", func(t *testing.T) {
+		lhs := CreateMatrix[int32](3, 2)
+		lhs.Data = []int32{1, -2, 3, -4, 5, -6, 7, -8, 9}
+
+		rhs := CreateMatrix[int32](3, 1)
+		rhs.Data = []int32{1, 2, 3}
+
+		expected := []int32{6, -12, 18}
+		res1 := lhs.Multiplication(0, 1, rhs)
+		res2 := lhs.ParallelMultiplication(0, 1, rhs)
+
+		compareMatrices(t, res1, res2)
+		compareMatrices(t, res1, &Matrix[int32]{X: 3, P: 1, Data: expected})
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		lhs := CreateMatrix[float64](2, 2)
+		lhs.Data = []float64{0.5, 1.5, -2, 4}
+
+		rhs := CreateMatrix[float64](2, 2)
+		rhs.Data = []float64{2, 0, 1, 0.25}
+
+		expected := []float64{2.5, 0.375, 0, 1}
+		res1 := lhs.Multiplication(0, 1, rhs)
+		res2 := lhs.ParallelMultiplication(0, 1, rhs)
+
+		compareMatrices(t, res1, res2)
+		compareMatrices(t, res1, &Matrix[float64]{X: 2, P: 2, Data: expected})
+	})
+
+	t.Run("complex128", func(t *testing.T) {
+		lhs := CreateMatrix[complex128](2, 1)
+		lhs.Data = []complex128{1 + 1i, 2 - 1i}
+
+		rhs := CreateMatrix[complex128](2, 1)
+		rhs.Data = []complex128{1i, 3}
+
+		expected := []complex128{-1 + 1i, 6 - 3i}
+		res1 := lhs.Multiplication(1, 0, rhs)
+		res2 := lhs.ParallelMultiplication(1, 0, rhs)
+
+		compareMatrices(t, res1, res2)
+		compareMatrices(t, res1, &Matrix[complex128]{X: 2, P: 1, Data: expected})
+	})
+
+	t.Run("uint8 matches uint32 modulo 256", func(t *testing.T) {
+		lhs32 := CreateMatrix[uint32](3, 2)
+		lhs8 := CreateMatrix[uint8](3, 2)
+		for i := range lhs32.Data {
+			lhs32.Data[i] = uint32(i * 17)
+			lhs8.Data[i] = uint8(i * 17)
+		}
+
+		res32 := lhs32.Multiplication(1, 1, lhs32)
+		res8 := lhs8.ParallelMultiplication(1, 1, lhs8)
+
+		if len(res32.Data) != len(res8.Data) {
+			t.Fatalf("Result length mismatch: %d vs %d", len(res32.Data), len(res8.Data))
+		}
+		for i := range res32.Data {
+			if uint8(res32.Data[i]) != res8.Data[i] {
+				t.Errorf("Result mismatch at index %d: got %d, expected %d", i, res8.Data[i], uint8(res32.Data[i]))
+			}
+		}
+	})
+}
+
 // Benchmark тесты для измерения производительности
 func BenchmarkMultiplications(b *testing.B) {
 	// Создаем матрицы для бенчмарков
-	lhs := CreateMatrix(3, 3)
+	lhs := CreateMatrix[uint32](3, 3)
 
 	// Заполняем данными
 	for i := range lhs.Data {
 		lhs.Data[i] = uint32(i % 5)
 	}
 
-	rhs := CreateMatrix(3, 3)
+	rhs := CreateMatrix[uint32](3, 3)
 
 	for i := range rhs.Data {
 		rhs.Data[i] = uint32((i + 2) % 5)
@@ -388,12 +503,12 @@ func BenchmarkMultiplications(b *testing.B) {
 // Benchmark тесты на разные типы умножения
 func BenchmarkMultiplicationsWithDetails(b *testing.B) {
 	// Создаем тестовые данные один раз
-	lhs := CreateMatrix(3, 3)
+	lhs := CreateMatrix[uint32](3, 3)
 	for i := range lhs.Data {
 		lhs.Data[i] = uint32(i % 5)
 	}
 
-	rhs := CreateMatrix(3, 3)
+	rhs := CreateMatrix[uint32](3, 3)
 	for i := range rhs.Data {
 		rhs.Data[i] = uint32((i + 2) % 5)
 	}
@@ -426,12 +541,12 @@ func BenchmarkMultiplicationsWithDetails(b *testing.B) {
 // Parallel Benchmark тесты на разные типы умножения
 func BenchmarkParallelMultiplicationsWithDetails(b *testing.B) {
 	// Создаем тестовые данные один раз
-	lhs := CreateMatrix(3, 3)
+	lhs := CreateMatrix[uint32](3, 3)
 	for i := range lhs.Data {
 		lhs.Data[i] = uint32(i % 5)
 	}
 
-	rhs := CreateMatrix(3, 3)
+	rhs := CreateMatrix[uint32](3, 3)
 	for i := range rhs.Data {
 		rhs.Data[i] = uint32((i + 2) % 5)
 	}
@@ -464,14 +579,14 @@ func BenchmarkParallelMultiplicationsWithDetails(b *testing.B) {
 // Benchmark тесты для измерения производительности
 func BenchmarkBigMultiplications(b *testing.B) {
 	// Создаем матрицы для бенчмарков
-	lhs := CreateMatrix(10, 6)
+	lhs := CreateMatrix[uint32](10, 6)
 
 	// Заполняем данными
 	for i := range lhs.Data {
 		lhs.Data[i] = uint32(i % 5)
 	}
 
-	rhs := CreateMatrix(10, 6)
+	rhs := CreateMatrix[uint32](10, 6)
 
 	for i := range rhs.Data {
 		rhs.Data[i] = uint32((i + 2) % 5)
@@ -547,7 +662,7 @@ func BenchmarkFullTest(b *testing.B) {
 
 	for lhsP := uint32(2); lhsP < 9; lhsP++ {
 
-		lhs := CreateMatrix(X, lhsP)
+		lhs := CreateMatrix[uint32](X, lhsP)
 
 		// Заполняем данными
 		for i := range lhs.Data {
@@ -556,7 +671,7 @@ func BenchmarkFullTest(b *testing.B) {
 
 		for rhsP := uint32(1); rhsP <= lhsP; rhsP++ {
 
-			rhs := CreateMatrix(X, rhsP)
+			rhs := CreateMatrix[uint32](X, rhsP)
 
 			for i := range rhs.Data {
 				rhs.Data[i] = uint32((i + 2) % 5)
@@ -597,7 +712,7 @@ func BenchmarkFullTest(b *testing.B) {
 // Вспомогательные функции
 
 // compareMatrices сравнивает две матрицы на идентичность
-func compareMatrices(t *testing.T, a, b *Matrix) {
+func compareMatrices[T Number](t *testing.T, a, b *Matrix[T]) {
 	t.Helper()
 
 	if a == nil || b == nil {
@@ -613,6 +728,10 @@ func compareMatrices(t *testing.T, a, b *Matrix) {
 		t.Errorf("P mismatch: %d vs %d", a.P, b.P)
 	}
 
+	if !slices.Equal(a.Shape(), b.Shape()) {
+		t.Errorf("Shape mismatch: %v vs %v", a.Shape(), b.Shape())
+	}
+
 	if len(a.Data) != len(b.Data) {
 		t.Errorf("Data length mismatch: %d vs %d", len(a.Data), len(b.Data))
 		return
@@ -620,7 +739,7 @@ func compareMatrices(t *testing.T, a, b *Matrix) {
 
 	for i := range a.Data {
 		if a.Data[i] != b.Data[i] {
-			t.Errorf("Data mismatch at index %d: %d vs %d", i, a.Data[i], b.Data[i])
+			t.Errorf("Data mismatch at index %d: %v vs %v", i, a.Data[i], b.Data[i])
 			return
 		}
 	}
@@ -0,0 +1,175 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+)
+
+// Unsigned объединяет беззнаковые типы элементов, над которыми определена
+// модулярная арифметика
+type Unsigned interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Ошибки модулярной арифметики
+var (
+	ErrInvalidModulus = errors.New("mdm: invalid modulus")
+	ErrNotInvertible  = errors.New("mdm: element is not invertible")
+)
+
+// GF — кольцо вычетов по модулю p (поле GF(p) для простого p).
+// Реализует Semiring, поэтому используется с MultiplySemiring и
+// ParallelMultiplySemiring. Элементы матриц должны быть приведены в
+// диапазон [0, p), например через ReduceMatrix.
+//
+// Для p < 2^32 произведения приводятся редукцией Барретта без деления;
+// для больших модулей используется 128-битное произведение
+type GF[T Unsigned] struct {
+	p uint64
+	// barrett = floor((2^64 - 1) / p), используется при p < 2^32
+	barrett uint64
+	small   bool
+}
+
+// NewGF создаёт кольцо вычетов по модулю p. Модуль должен быть не меньше 2,
+// а все вычеты [0, p) должны помещаться в T
+func NewGF[T Unsigned](p uint64) (*GF[T], error) {
+	if p < 2 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidModulus, p)
+	}
+	if p-1 > maxUnsigned[T]() {
+		return nil, fmt.Errorf("%w: %d does not fit %v", ErrInvalidModulus, p, reflect.TypeFor[T]())
+	}
+	return &GF[T]{
+		p:       p,
+		barrett: math.MaxUint64 / p,
+		small:   p <= math.MaxUint32,
+	}, nil
+}
+
+// Modulus возвращает модуль кольца
+func (f *GF[T]) Modulus() uint64 {
+	return f.p
+}
+
+// Zero возвращает нулевой вычет
+func (f *GF[T]) Zero() T {
+	return 0
+}
+
+// Add возвращает (a + b) mod p для приведённых a и b
+func (f *GF[T]) Add(a, b T) T {
+	return T(f.add(uint64(a), uint64(b)))
+}
+
+// Sub возвращает (a - b) mod p для приведённых a и b
+func (f *GF[T]) Sub(a, b T) T {
+	return T(f.sub(uint64(a), uint64(b)))
+}
+
+// Neg возвращает (-a) mod p для приведённого a
+func (f *GF[T]) Neg(a T) T {
+	return T(f.sub(0, uint64(a)))
+}
+
+// Mul возвращает (a * b) mod p для приведённых a и b
+func (f *GF[T]) Mul(a, b T) T {
+	return T(f.mul(uint64(a), uint64(b)))
+}
+
+// Reduce приводит произвольное значение в диапазон [0, p)
+func (f *GF[T]) Reduce(a T) T {
+	return T(uint64(a) % f.p)
+}
+
+// ReduceMatrix приводит все элементы матрицы в диапазон [0, p) на месте
+func (f *GF[T]) ReduceMatrix(m *Matrix[T]) {
+	for i, v := range m.Data {
+		m.Data[i] = f.Reduce(v)
+	}
+}
+
+// Pow возвращает a^e mod p возведением в степень двоичным методом
+func (f *GF[T]) Pow(a T, e uint64) T {
+	result := uint64(1) % f.p
+	base := uint64(a) % f.p
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = f.mul(result, base)
+		}
+		base = f.mul(base, base)
+	}
+	return T(result)
+}
+
+// Inv возвращает обратный к a по умножению вычет. Для составного модуля
+// обратимы только элементы, взаимно простые с p; иначе возвращается
+// ErrNotInvertible
+func (f *GF[T]) Inv(a T) (T, error) {
+	// Расширенный алгоритм Евклида; коэффициенты хранятся по модулю p,
+	// поэтому отрицательные значения не возникают
+	r0, r1 := f.p, uint64(a)%f.p
+	t0, t1 := uint64(0), uint64(1)
+	for r1 != 0 {
+		q := r0 / r1
+		r0, r1 = r1, r0-q*r1
+		t0, t1 = t1, f.sub(t0, f.mul(q%f.p, t1))
+	}
+	if r0 != 1 {
+		return 0, fmt.Errorf("%w: %d mod %d", ErrNotInvertible, a, f.p)
+	}
+	return T(t0), nil
+}
+
+func (f *GF[T]) add(a, b uint64) uint64 {
+	sum, carry := bits.Add64(a, b, 0)
+	if carry != 0 || sum >= f.p {
+		sum -= f.p
+	}
+	return sum
+}
+
+func (f *GF[T]) sub(a, b uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return f.p - (b - a)
+}
+
+func (f *GF[T]) mul(a, b uint64) uint64 {
+	if f.small {
+		return f.barrettReduce(a * b)
+	}
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, f.p)
+}
+
+// barrettReduce вычисляет x mod p для p < 2^32 без деления:
+// частное оценивается как старшее слово x * floor((2^64 - 1) / p)
+// и занижено не более чем на 2
+func (f *GF[T]) barrettReduce(x uint64) uint64 {
+	q, _ := bits.Mul64(x, f.barrett)
+	r := x - q*f.p
+	for r >= f.p {
+		r -= f.p
+	}
+	return r
+}
+
+// dot вычисляет свёртку одной ячейки по модулю p без вызовов через интерфейс
+func (f *GF[T]) dot(lhs, rhs []T, lhsBase, rhsBase int, offsets []int) T {
+	var sum uint64
+	for k, offset := range offsets {
+		sum = f.add(sum, f.mul(uint64(lhs[lhsBase+k]), uint64(rhs[rhsBase+offset])))
+	}
+	return T(sum)
+}
+
+// maxUnsigned возвращает наибольшее значение беззнакового типа T
+func maxUnsigned[T Unsigned]() uint64 {
+	var zero T
+	return uint64(zero - 1)
+}
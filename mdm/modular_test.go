@@ -0,0 +1,165 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// TestNewGF тестирует проверку модуля
+func TestNewGF(t *testing.T) {
+	if _, err := NewGF[uint32](1); !errors.Is(err, ErrInvalidModulus) {
+		t.Errorf("Expected ErrInvalidModulus for p=1, got %v", err)
+	}
+	if _, err := NewGF[uint8](257); !errors.Is(err, ErrInvalidModulus) {
+		t.Errorf("Expected ErrInvalidModulus for p=257 in uint8, got %v", err)
+	}
+	if _, err := NewGF[uint8](256); err != nil {
+		t.Errorf("p=256 must fit uint8 residues: %v", err)
+	}
+}
+
+// TestGFArithmetic тестирует модулярные операции против math/big
+func TestGFArithmetic(t *testing.T) {
+	moduli := []uint64{2, 7, 65521, 4294967291, 2305843009213693951, 18446744073709551557}
+	values := []uint64{0, 1, 2, 3, 12345, 1<<32 - 1, 1<<61 + 7, 1<<64 - 1}
+
+	for _, p := range moduli {
+		t.Run(fmt.Sprintf("p=%d", p), func(t *testing.T) {
+			f, err := NewGF[uint64](p)
+			if err != nil {
+				t.Fatalf("NewGF: %v", err)
+			}
+			bp := new(big.Int).SetUint64(p)
+
+			for _, x := range values {
+				for _, y := range values {
+					a, b := f.Reduce(x), f.Reduce(y)
+					ba, bb := new(big.Int).SetUint64(a), new(big.Int).SetUint64(b)
+
+					expected := new(big.Int).Mod(new(big.Int).Mul(ba, bb), bp).Uint64()
+					if got := f.Mul(a, b); got != expected {
+						t.Errorf("Mul(%d, %d) = %d, expected %d", a, b, got, expected)
+					}
+					expected = new(big.Int).Mod(new(big.Int).Add(ba, bb), bp).Uint64()
+					if got := f.Add(a, b); got != expected {
+						t.Errorf("Add(%d, %d) = %d, expected %d", a, b, got, expected)
+					}
+					expected = new(big.Int).Mod(new(big.Int).Sub(ba, bb), bp).Uint64()
+					if got := f.Sub(a, b); got != expected {
+						t.Errorf("Sub(%d, %d) = %d, expected %d", a, b, got, expected)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestGFInverse тестирует обратные элементы и возведение в степень
+func TestGFInverse(t *testing.T) {
+	f, err := NewGF[uint32](65521)
+	if err != nil {
+		t.Fatalf("NewGF: %v", err)
+	}
+
+	for _, a := range []uint32{1, 2, 3, 1000, 65520} {
+		inv, err := f.Inv(a)
+		if err != nil {
+			t.Fatalf("Inv(%d): %v", a, err)
+		}
+		if f.Mul(a, inv) != 1 {
+			t.Errorf("Inv(%d) = %d is not an inverse", a, inv)
+		}
+		// Малая теорема Ферма: a^(p-1) = 1
+		if f.Pow(a, 65520) != 1 {
+			t.Errorf("Pow(%d, p-1) != 1", a)
+		}
+	}
+
+	if _, err := f.Inv(0); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("Expected ErrNotInvertible for 0, got %v", err)
+	}
+
+	composite, _ := NewGF[uint16](12)
+	if _, err := composite.Inv(4); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("Expected ErrNotInvertible for 4 mod 12, got %v", err)
+	}
+	if inv, err := composite.Inv(5); err != nil || composite.Mul(5, inv) != 1 {
+		t.Errorf("Inv(5) mod 12 = %d, %v", inv, err)
+	}
+}
+
+// TestModularMultiplication тестирует (λ,μ)-умножение по модулю против точного вычисления
+func TestModularMultiplication(t *testing.T) {
+	const p = 4294967291 // наибольшее простое меньше 2^32
+
+	f, err := NewGF[uint32](p)
+	if err != nil {
+		t.Fatalf("NewGF: %v", err)
+	}
+
+	lhs := CreateMatrix[uint32](3, 3)
+	rhs := CreateMatrix[uint32](3, 3)
+	for i := range lhs.Data {
+		lhs.Data[i] = uint32(p - 1 - i*1000003)
+		rhs.Data[i] = uint32(i*i*7919 + 13)
+	}
+	f.ReduceMatrix(lhs)
+	f.ReduceMatrix(rhs)
+
+	for _, params := range [][2]uint32{{0, 1}, {1, 1}, {0, 2}, {1, 0}} {
+		lambda, mu := params[0], params[1]
+		t.Run(fmt.Sprintf("lambda=%d_mu=%d", lambda, mu), func(t *testing.T) {
+			// Эталон: точное произведение в uint64 без переполнения для μ ≤ 2
+			lhs64 := &Matrix[uint64]{X: 3, P: 3, Data: make([]uint64, len(lhs.Data))}
+			rhs64 := &Matrix[uint64]{X: 3, P: 3, Data: make([]uint64, len(rhs.Data))}
+			for i := range lhs.Data {
+				lhs64.Data[i] = uint64(lhs.Data[i])
+				rhs64.Data[i] = uint64(rhs.Data[i])
+			}
+			bigMod, _ := NewGF[uint64](p)
+			expected := referenceSemiring[uint64](bigMod, lhs64, rhs64, lambda, mu)
+
+			for _, parallel := range []bool{false, true} {
+				var result *Matrix[uint32]
+				if parallel {
+					result, err = lhs.ParallelMultiplySemiring(f, lambda, mu, rhs)
+				} else {
+					result, err = lhs.MultiplySemiring(f, lambda, mu, rhs)
+				}
+				if err != nil {
+					t.Fatalf("multiply (parallel=%v): %v", parallel, err)
+				}
+				for i := range result.Data {
+					if uint64(result.Data[i]) != expected.Data[i] {
+						t.Fatalf("Result mismatch at index %d (parallel=%v): got %d, expected %d",
+							i, parallel, result.Data[i], expected.Data[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkModularMultiplication сравнивает модулярное умножение с обычным
+func BenchmarkModularMultiplication(b *testing.B) {
+	f, _ := NewGF[uint32](65521)
+	lhs := CreateMatrix[uint32](10, 4)
+	rhs := CreateMatrix[uint32](10, 4)
+	for i := range lhs.Data {
+		lhs.Data[i] = uint32(i % 65521)
+		rhs.Data[i] = uint32((i * 7) % 65521)
+	}
+
+	b.Run("GF", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = lhs.MultiplySemiring(f, 1, 2, rhs)
+		}
+	})
+	b.Run("Wrapping", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			lhs.Multiplication(1, 2, rhs)
+		}
+	})
+}
@@ -0,0 +1,459 @@
+package mdm
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Формат NumPy .npy: магическая строка, версия, длина заголовка и заголовок —
+// литерал словаря Python с ключами descr, fortran_order и shape,
+// дополненный пробелами до границы 64 байт; за ним следуют данные
+const (
+	npyMagic     = "\x93NUMPY"
+	npyAlignment = 64
+	npyChunk     = 1 << 16
+)
+
+var (
+	npyDescr   = regexp.MustCompile(`['"]descr['"]\s*:\s*['"]([^'"]*)['"]`)
+	npyFortran = regexp.MustCompile(`['"]fortran_order['"]\s*:\s*(True|False)`)
+	npyShape   = regexp.MustCompile(`['"]shape['"]\s*:\s*\(([^)]*)\)`)
+)
+
+// npyKinds сопоставляет типам элементов коды dtype NumPy без порядка байтов
+var npyKinds = map[reflect.Kind]string{
+	reflect.Int8: "i1", reflect.Int16: "i2", reflect.Int32: "i4", reflect.Int64: "i8",
+	reflect.Uint8: "u1", reflect.Uint16: "u2", reflect.Uint32: "u4", reflect.Uint64: "u8",
+	reflect.Float32: "f4", reflect.Float64: "f8",
+	reflect.Complex64: "c8", reflect.Complex128: "c16",
+}
+
+// npyDtype — разобранный dtype: класс ('b', 'i', 'u', 'f', 'c'), размер
+// элемента в байтах и порядок байтов
+type npyDtype struct {
+	class byte
+	size  int
+	order binary.ByteOrder
+}
+
+// SaveNPY записывает кубическую матрицу в w в формате NumPy .npy версии
+// 1.0 (2.0 для длинных заголовков). Массив имеет форму (X,)*P и порядок C,
+// совпадающий с порядком Data; данные пишутся в порядке байтов машины без
+// копирования. Некубические матрицы дают ErrDimensionMismatch
+func SaveNPY[T Number](w io.Writer, m *Matrix[T]) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+	if !m.IsCubic() {
+		return fmt.Errorf("%w: npy requires a cubic matrix, got shape %v", ErrDimensionMismatch, m.shape())
+	}
+	kind := reflect.TypeFor[T]().Kind()
+	order := "<"
+	switch {
+	case unsafe.Sizeof(T(0)) == 1:
+		order = "|"
+	case nativeBigEndian:
+		order = ">"
+	}
+
+	shape := make([]string, 0, m.P)
+	for _, n := range m.shape() {
+		shape = append(shape, strconv.Itoa(n))
+	}
+	tuple := strings.Join(shape, ", ")
+	if len(shape) == 1 {
+		tuple += ","
+	}
+	dict := fmt.Sprintf("{'descr': '%s%s', 'fortran_order': False, 'shape': (%s), }", order, npyKinds[kind], tuple)
+
+	// Версия 1.0 хранит длину заголовка в uint16, 2.0 — в uint32
+	prefix := []byte(npyMagic + "\x01\x00")
+	lenSize := 2
+	if len(dict)+len(prefix)+lenSize+1 > math.MaxUint16 {
+		prefix[len(npyMagic)] = 2
+		lenSize = 4
+	}
+	total := len(prefix) + lenSize + len(dict) + 1
+	dict += strings.Repeat(" ", (npyAlignment-total%npyAlignment)%npyAlignment) + "\n"
+	if lenSize == 2 {
+		prefix = binary.LittleEndian.AppendUint16(prefix, uint16(len(dict)))
+	} else {
+		prefix = binary.LittleEndian.AppendUint32(prefix, uint32(len(dict)))
+	}
+
+	if _, err := w.Write(append(prefix, dict...)); err != nil {
+		return err
+	}
+	_, err := w.Write(dataBytes(m.Data))
+	return err
+}
+
+// NPYHeader — разобранный заголовок .npy. Заголовок получают только из
+// ReadNPYHeader; созданный вручную NPYHeader отвергается ReadNPYData
+type NPYHeader struct {
+	// Kind — тип элемента, совпадающий с dtype: reflect.Int8 ...
+	// reflect.Complex128; логическому dtype соответствует reflect.Uint8
+	Kind  reflect.Kind
+	Shape []int
+	// FortranOrder — данные записаны в порядке Fortran
+	FortranOrder bool
+
+	dtype npyDtype
+}
+
+// LoadNPY читает массив NumPy .npy версий 1.0–3.0 формы (X,)*P в
+// кубическую матрицу; массивы других форм дают ErrDimensionMismatch.
+// Массивы в порядке Fortran переставляются в порядок C. Целые, беззнаковые, вещественные,
+// комплексные и логические dtype в любом порядке байтов преобразуются к T
+// по правилам преобразования Go; для dtype, совпадающего с T, данные
+// читаются сразу в Data. Прочие dtype дают ErrUnsupportedType, ошибки
+// формата сопоставляются с ErrInvalidFormat
+func LoadNPY[T Number](r io.Reader) (*Matrix[T], error) {
+	h, err := ReadNPYHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return ReadNPYData[T](r, h)
+}
+
+// ReadNPYHeader читает заголовок .npy. Данные читаются затем ReadNPYData
+// с тем же заголовком, что позволяет выбрать T по h.Kind. Ошибки те же,
+// что у LoadNPY
+func ReadNPYHeader(r io.Reader) (*NPYHeader, error) {
+	dtype, fortran, shape, err := readNPYHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range shape {
+		if n != shape[0] {
+			return nil, fmt.Errorf("%w: npy shape %v is not cubic", ErrDimensionMismatch, shape)
+		}
+	}
+	return &NPYHeader{Kind: dtype.kind(), Shape: shape, FortranOrder: fortran, dtype: dtype}, nil
+}
+
+// ReadNPYData читает данные .npy с заголовком h, прочитанным ReadNPYHeader
+// из того же r, и преобразует их к T как LoadNPY
+func ReadNPYData[T Number](r io.Reader, h *NPYHeader) (*Matrix[T], error) {
+	if h == nil || h.dtype.size == 0 {
+		return nil, fmt.Errorf("%w: header was not read by ReadNPYHeader", ErrInvalidFormat)
+	}
+	dtype, shape := h.dtype, h.Shape
+	size, err := checkShape(shape)
+	if err != nil {
+		return nil, err
+	}
+	if size > math.MaxInt/max(dtype.size, int(unsafe.Sizeof(T(0)))) {
+		return nil, fmt.Errorf("%w: shape %v", ErrSizeOverflow, shape)
+	}
+	// Память под данные выделяется сразу, если её подтверждает длина r,
+	// иначе после получения части данных, см. loadElements
+	var data []T
+	kind := reflect.TypeFor[T]().Kind()
+	known := hasBytes(r, int64(size*dtype.size))
+	if kind == dtype.kind() &&
+		(dtype.size == 1 || (dtype.order == binary.BigEndian) == nativeBigEndian) {
+		data, err = readElements[T](r, size, known)
+	} else {
+		buf := make([]byte, min(size*dtype.size, npyChunk-npyChunk%dtype.size))
+		data, err = loadElements(size, known, func(dst []T) error {
+			for len(dst) > 0 {
+				chunk := buf[:min(len(buf), len(dst)*dtype.size)]
+				if _, err := io.ReadFull(r, chunk); err != nil {
+					return noEOF(err)
+				}
+				for i := 0; i < len(chunk); i += dtype.size {
+					dst[i/dtype.size] = convertScalar[T](kind, dtype.decode(chunk[i:i+dtype.size]))
+				}
+				dst = dst[len(chunk)/dtype.size:]
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: npy data: %w", ErrInvalidFormat, err)
+	}
+	m := fromShape(shape, data)
+
+	if h.FortranOrder {
+		perm := make([]int, len(shape))
+		for i := range perm {
+			perm[i] = len(perm) - 1 - i
+		}
+		return m.Transpose(perm)
+	}
+	return m, nil
+}
+
+// readNPYHeader читает и разбирает заголовок .npy
+func readNPYHeader(r io.Reader) (npyDtype, bool, []int, error) {
+	var dtype npyDtype
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return dtype, false, nil, fmt.Errorf("%w: npy header: %w", ErrInvalidFormat, err)
+	}
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return dtype, false, nil, fmt.Errorf("%w: bad npy magic %q", ErrInvalidFormat, prefix[:len(npyMagic)])
+	}
+	var headerLen int
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		b := make([]byte, 2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return dtype, false, nil, fmt.Errorf("%w: npy header: %w", ErrInvalidFormat, noEOF(err))
+		}
+		headerLen = int(binary.LittleEndian.Uint16(b))
+	case 2, 3:
+		b := make([]byte, 4)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return dtype, false, nil, fmt.Errorf("%w: npy header: %w", ErrInvalidFormat, noEOF(err))
+		}
+		headerLen = int(binary.LittleEndian.Uint32(b))
+	default:
+		return dtype, false, nil, fmt.Errorf("%w: unsupported npy version %d", ErrInvalidFormat, major)
+	}
+
+	// Заголовок читается частями, чтобы длина из повреждённого файла
+	// не приводила к выделению лишней памяти
+	var header strings.Builder
+	if _, err := io.CopyN(&header, r, int64(headerLen)); err != nil {
+		return dtype, false, nil, fmt.Errorf("%w: npy header: %w", ErrInvalidFormat, noEOF(err))
+	}
+	dict := header.String()
+
+	descr := npyDescr.FindStringSubmatch(dict)
+	fortran := npyFortran.FindStringSubmatch(dict)
+	shapeMatch := npyShape.FindStringSubmatch(dict)
+	if descr == nil || fortran == nil || shapeMatch == nil {
+		return dtype, false, nil, fmt.Errorf("%w: npy header %q", ErrInvalidFormat, strings.TrimSpace(dict))
+	}
+	dtype, err := parseNPYDescr(descr[1])
+	if err != nil {
+		return dtype, false, nil, err
+	}
+	var shape []int
+	for _, field := range strings.Split(shapeMatch[1], ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(field, "L"))
+		if err != nil {
+			return dtype, false, nil, fmt.Errorf("%w: npy shape (%s)", ErrInvalidFormat, shapeMatch[1])
+		}
+		shape = append(shape, n)
+	}
+	return dtype, fortran[1] == "True", shape, nil
+}
+
+// parseNPYDescr разбирает строку dtype вида "<f8"
+func parseNPYDescr(descr string) (npyDtype, error) {
+	dtype := npyDtype{order: binary.NativeEndian}
+	if descr == "" {
+		return dtype, fmt.Errorf("%w: empty npy dtype", ErrInvalidFormat)
+	}
+	switch descr[0] {
+	case '<':
+		dtype.order = binary.LittleEndian
+		descr = descr[1:]
+	case '>':
+		dtype.order = binary.BigEndian
+		descr = descr[1:]
+	case '|', '=':
+		descr = descr[1:]
+	}
+	if len(descr) < 2 {
+		return dtype, fmt.Errorf("%w: npy dtype %q", ErrUnsupportedType, descr)
+	}
+	dtype.class = descr[0]
+	size, err := strconv.Atoi(descr[1:])
+	if err != nil {
+		return dtype, fmt.Errorf("%w: npy dtype %q", ErrUnsupportedType, descr)
+	}
+	dtype.size = size
+	valid := false
+	switch dtype.class {
+	case 'b':
+		valid = size == 1
+	case 'i', 'u':
+		valid = size == 1 || size == 2 || size == 4 || size == 8
+	case 'f':
+		valid = size == 4 || size == 8
+	case 'c':
+		valid = size == 8 || size == 16
+	}
+	if !valid {
+		return dtype, fmt.Errorf("%w: npy dtype %q", ErrUnsupportedType, descr)
+	}
+	return dtype, nil
+}
+
+// kind возвращает тип элемента Go, совпадающий с dtype по представлению
+func (d npyDtype) kind() reflect.Kind {
+	if d.class == 'b' {
+		return reflect.Uint8
+	}
+	code := fmt.Sprintf("%c%d", d.class, d.size)
+	for kind, c := range npyKinds {
+		if c == code {
+			return kind
+		}
+	}
+	return reflect.Invalid
+}
+
+// scalar — значение элемента .npy до преобразования к T
+type scalar struct {
+	class byte
+	i     int64
+	u     uint64
+	c     complex128
+}
+
+// decode декодирует один элемент из b
+func (d npyDtype) decode(b []byte) scalar {
+	switch d.class {
+	case 'f':
+		return scalar{class: 'f', c: complex(d.decodeFloat(b, d.size), 0)}
+	case 'c':
+		half := d.size / 2
+		return scalar{class: 'c', c: complex(d.decodeFloat(b[:half], half), d.decodeFloat(b[half:], half))}
+	}
+	var u uint64
+	switch d.size {
+	case 1:
+		u = uint64(b[0])
+	case 2:
+		u = uint64(d.order.Uint16(b))
+	case 4:
+		u = uint64(d.order.Uint32(b))
+	case 8:
+		u = d.order.Uint64(b)
+	}
+	if d.class == 'i' {
+		shift := 64 - 8*d.size
+		return scalar{class: 'i', i: int64(u<<shift) >> shift}
+	}
+	return scalar{class: 'u', u: u}
+}
+
+func (d npyDtype) decodeFloat(b []byte, size int) float64 {
+	if size == 4 {
+		return float64(math.Float32frombits(d.order.Uint32(b)))
+	}
+	return math.Float64frombits(d.order.Uint64(b))
+}
+
+// convertScalar преобразует значение к T с типом элемента kind;
+// комплексные значения при преобразовании к вещественным теряют мнимую часть
+func convertScalar[T Number](kind reflect.Kind, s scalar) T {
+	var i int64
+	var u uint64
+	var f float64
+	switch s.class {
+	case 'i':
+		i, u, f = s.i, uint64(s.i), float64(s.i)
+	case 'u':
+		i, u, f = int64(s.u), s.u, float64(s.u)
+	default:
+		f = real(s.c)
+		i, u = int64(f), uint64(f)
+	}
+	c := s.c
+	if s.class == 'i' || s.class == 'u' {
+		c = complex(f, 0)
+	}
+
+	var v T
+	p := unsafe.Pointer(&v)
+	switch kind {
+	case reflect.Int8:
+		*(*int8)(p) = int8(i)
+	case reflect.Int16:
+		*(*int16)(p) = int16(i)
+	case reflect.Int32:
+		*(*int32)(p) = int32(i)
+	case reflect.Int64:
+		*(*int64)(p) = i
+	case reflect.Uint8:
+		*(*uint8)(p) = uint8(u)
+	case reflect.Uint16:
+		*(*uint16)(p) = uint16(u)
+	case reflect.Uint32:
+		*(*uint32)(p) = uint32(u)
+	case reflect.Uint64:
+		*(*uint64)(p) = u
+	case reflect.Float32:
+		*(*float32)(p) = float32(f)
+	case reflect.Float64:
+		*(*float64)(p) = f
+	case reflect.Complex64:
+		*(*complex64)(p) = complex64(c)
+	case reflect.Complex128:
+		*(*complex128)(p) = c
+	}
+	return v
+}
+
+// SaveNPZ записывает матрицы в архив .npz без сжатия, совместимый с
+// numpy.load: каждая матрица хранится в файле name.npy. Файлы
+// упорядочены по именам
+func SaveNPZ[T Number](w io.Writer, arrays map[string]*Matrix[T]) error {
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if err := SaveNPY(fw, arrays[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// LoadNPZ читает все массивы архива .npz размера size, сжатого или нет,
+// и преобразует их к T как LoadNPY. Ключи результата — имена файлов без
+// расширения .npy; файлы с другими расширениями пропускаются
+func LoadNPZ[T Number](r io.ReaderAt, size int64) (map[string]*Matrix[T], error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: npz: %w", ErrInvalidFormat, err)
+	}
+	arrays := make(map[string]*Matrix[T])
+	for _, f := range zr.File {
+		name, ok := strings.CutSuffix(f.Name, ".npy")
+		if !ok {
+			continue
+		}
+		m, err := loadNPZFile[T](f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		arrays[name] = m
+	}
+	return arrays, nil
+}
+
+func loadNPZFile[T Number](f *zip.File) (*Matrix[T], error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: npz: %w", ErrInvalidFormat, err)
+	}
+	defer rc.Close()
+	return LoadNPY[T](rc)
+}
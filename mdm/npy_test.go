@@ -0,0 +1,229 @@
+package mdm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// npyFile собирает файл .npy версии 1.0 с заголовком dict и данными data
+func npyFile(dict string, data []byte) []byte {
+	b := []byte(npyMagic + "\x01\x00")
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(dict)+1))
+	b = append(b, dict...)
+	b = append(b, '\n')
+	return append(b, data...)
+}
+
+// TestNPYRoundTrip тестирует запись и чтение .npy
+func TestNPYRoundTrip(t *testing.T) {
+	m := CreateMatrix[float64](3, 2)
+	for i := range m.Data {
+		m.Data[i] = float64(i) / 4
+	}
+	var buf bytes.Buffer
+	if err := SaveNPY(&buf, m); err != nil {
+		t.Fatalf("SaveNPY: %v", err)
+	}
+	header := buf.String()[:strings.IndexByte(buf.String(), '\n')+1]
+	if len(header)%npyAlignment != 0 || !strings.Contains(header, "{'descr': '<f8', 'fortran_order': False, 'shape': (3, 3), }") {
+		t.Errorf("Unexpected header %q", header)
+	}
+	got, err := LoadNPY[float64](&buf)
+	if err != nil {
+		t.Fatalf("LoadNPY: %v", err)
+	}
+	compareMatrices(t, m, got)
+
+	shaped := newSequence(t, 4)
+	buf.Reset()
+	SaveNPY(&buf, shaped)
+	if !strings.Contains(buf.String(), "'shape': (4,)") {
+		t.Errorf("1-d shape is not a tuple: %q", buf.String())
+	}
+	gotShaped, err := LoadNPY[int64](&buf)
+	if err != nil {
+		t.Fatalf("LoadNPY: %v", err)
+	}
+	compareMatrices(t, shaped, gotShaped)
+
+	c := CreateMatrix[complex64](2, 1)
+	copy(c.Data, []complex64{1 + 2i, -3 - 4i})
+	buf.Reset()
+	SaveNPY(&buf, c)
+	gotComplex, err := LoadNPY[complex64](&buf)
+	if err != nil {
+		t.Fatalf("LoadNPY: %v", err)
+	}
+	compareMatrices(t, c, gotComplex)
+}
+
+// TestNPYConversion тестирует преобразование dtype, порядка байтов и порядка Fortran
+func TestNPYConversion(t *testing.T) {
+	var be []byte
+	for _, v := range []float32{1.5, -2, 3.75, 4} {
+		be = binary.BigEndian.AppendUint32(be, math.Float32bits(v))
+	}
+	var i16 []byte
+	for _, v := range []int16{-1, 2, -300, 4} {
+		i16 = binary.LittleEndian.AppendUint16(i16, uint16(v))
+	}
+
+	t.Run("f4 to int64", func(t *testing.T) {
+		m, err := LoadNPY[int64](bytes.NewReader(npyFile("{'descr': '>f4', 'fortran_order': False, 'shape': (2, 2), }", be)))
+		if err != nil {
+			t.Fatalf("LoadNPY: %v", err)
+		}
+		if !slices.Equal(m.Data, []int64{1, -2, 3, 4}) || m.X != 2 || m.P != 2 {
+			t.Errorf("Got %+v", m)
+		}
+	})
+	t.Run("i2 to complex128", func(t *testing.T) {
+		m, err := LoadNPY[complex128](bytes.NewReader(npyFile("{'descr': '<i2', 'fortran_order': False, 'shape': (4,), }", i16)))
+		if err != nil {
+			t.Fatalf("LoadNPY: %v", err)
+		}
+		if !slices.Equal(m.Data, []complex128{-1, 2, -300, 4}) {
+			t.Errorf("Got %v", m.Data)
+		}
+	})
+	t.Run("bool", func(t *testing.T) {
+		m, err := LoadNPY[uint8](bytes.NewReader(npyFile("{'descr': '|b1', 'fortran_order': False, 'shape': (), }", []byte{1})))
+		if err != nil {
+			t.Fatalf("LoadNPY: %v", err)
+		}
+		if m.P != 0 || !slices.Equal(m.Data, []uint8{1}) {
+			t.Errorf("Got %+v", m)
+		}
+	})
+	t.Run("fortran", func(t *testing.T) {
+		// Массив 3x3 [[0, 1, 2], [3, 4, 5], [6, 7, 8]] в порядке Fortran
+		var data []byte
+		for _, v := range []int64{0, 3, 6, 1, 4, 7, 2, 5, 8} {
+			data = binary.LittleEndian.AppendUint64(data, uint64(v))
+		}
+		m, err := LoadNPY[int64](bytes.NewReader(npyFile("{'descr': '<i8', 'fortran_order': True, 'shape': (3, 3), }", data)))
+		if err != nil {
+			t.Fatalf("LoadNPY: %v", err)
+		}
+		compareMatrices(t, newSequence(t, 3, 3), m)
+	})
+}
+
+// TestNPYHeader тестирует выбор типа по dtype заголовка
+func TestNPYHeader(t *testing.T) {
+	for descr, kind := range map[string]reflect.Kind{"<i2": reflect.Int16, ">u8": reflect.Uint64, "|b1": reflect.Uint8, "<c8": reflect.Complex64} {
+		h, err := ReadNPYHeader(bytes.NewReader(npyFile("{'descr': '"+descr+"', 'fortran_order': True, 'shape': (2, 2), }", nil)))
+		if err != nil {
+			t.Fatalf("%s: ReadNPYHeader: %v", descr, err)
+		}
+		if h.Kind != kind || !h.FortranOrder || !slices.Equal(h.Shape, []int{2, 2}) {
+			t.Errorf("%s: unexpected header %+v", descr, h)
+		}
+	}
+}
+
+// TestNPYErrors тестирует обнаружение неверных файлов
+func TestNPYErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		target error
+	}{
+		{"magic", []byte("NUMPY\x01\x00\x00\x00"), ErrInvalidFormat},
+		{"version", []byte(npyMagic + "\x07\x00\x00\x00"), ErrInvalidFormat},
+		{"header", npyFile("{'descr': '<f8'}", nil), ErrInvalidFormat},
+		{"dtype", npyFile("{'descr': '<U5', 'fortran_order': False, 'shape': (1,), }", make([]byte, 20)), ErrUnsupportedType},
+		{"float16", npyFile("{'descr': '<f2', 'fortran_order': False, 'shape': (1,), }", make([]byte, 2)), ErrUnsupportedType},
+		{"non-cubic", npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }", make([]byte, 48)), ErrDimensionMismatch},
+		{"shape", npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (-1,), }", nil), ErrInvalidShape},
+		{"truncated", npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (2,), }", make([]byte, 12)), ErrInvalidFormat},
+		{"huge shape", npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (16384, 16384, 16384, 16384), }", make([]byte, 4)), ErrInvalidFormat},
+		{"huge converted shape", npyFile("{'descr': '<i2', 'fortran_order': False, 'shape': (16384, 16384, 16384, 16384), }", make([]byte, 4)), ErrInvalidFormat},
+		{"size overflow", npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (4294967295, 4294967295, 4294967295), }", nil), ErrSizeOverflow},
+	}
+	for _, tt := range tests {
+		if _, err := LoadNPY[float64](bytes.NewReader(tt.data)); !errors.Is(err, tt.target) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.target, err)
+		}
+	}
+
+	if _, err := ReadNPYData[float64](bytes.NewReader(nil), &NPYHeader{Kind: reflect.Float64}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Handmade header: expected ErrInvalidFormat, got %v", err)
+	}
+	if err := SaveNPY(io.Discard, newSequence(t, 2, 3)); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Non-cubic SaveNPY: expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+// TestNPYReadMemory проверяет, что данные читаются в один выделенный срез:
+// при известной длине входа без копий, а из потока неизвестной длины —
+// с копированием лишь части, прочитанной до выделения среза
+func TestNPYReadMemory(t *testing.T) {
+	const x = 4096
+	payload := uint64(8 * x * x)
+	tests := []struct {
+		name  string
+		descr string
+		size  int
+	}{
+		{"direct", "<f8", 8},
+		{"converted", "<i4", 4},
+	}
+	for _, tt := range tests {
+		file := npyFile(fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%d, %d), }", tt.descr, x, x), make([]byte, tt.size*x*x))
+		for _, stream := range []bool{false, true} {
+			var r io.Reader = bytes.NewReader(file)
+			limit := payload + payload/16
+			if stream {
+				r = struct{ io.Reader }{r}
+				limit = payload + payload>>trustShift + 2*readChunk
+			}
+			var m *Matrix[float64]
+			var err error
+			alloc := allocated(func() { m, err = LoadNPY[float64](r) })
+			if err != nil {
+				t.Fatalf("%s, stream=%v: LoadNPY: %v", tt.name, stream, err)
+			}
+			if alloc > limit {
+				t.Errorf("%s, stream=%v: allocated %d bytes for %d bytes of data", tt.name, stream, alloc, payload)
+			}
+			if m.X != x || m.P != 2 {
+				t.Errorf("%s, stream=%v: got shape %v", tt.name, stream, m.Shape())
+			}
+		}
+	}
+}
+
+// TestNPZ тестирует архив из нескольких матриц
+func TestNPZ(t *testing.T) {
+	arrays := map[string]*Matrix[int64]{
+		"a": newSequence(t, 3, 3),
+		"b": newSequence(t, 2, 2, 2),
+	}
+	var buf bytes.Buffer
+	if err := SaveNPZ(&buf, arrays); err != nil {
+		t.Fatalf("SaveNPZ: %v", err)
+	}
+	got, err := LoadNPZ[int64](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("LoadNPZ: %v", err)
+	}
+	if len(got) != len(arrays) {
+		t.Fatalf("Got %d arrays, expected %d", len(got), len(arrays))
+	}
+	for name, m := range arrays {
+		compareMatrices(t, m, got[name])
+	}
+
+	if _, err := LoadNPZ[int64](bytes.NewReader([]byte("not a zip")), 9); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat, got %v", err)
+	}
+}
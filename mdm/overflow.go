@@ -0,0 +1,204 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sync"
+)
+
+// OverflowPolicy задаёт поведение uint32-умножения при переполнении суммы
+type OverflowPolicy int
+
+const (
+	// OverflowWrap — сложение по модулю 2^32 (поведение Multiplication)
+	OverflowWrap OverflowPolicy = iota
+	// OverflowChecked — ошибка *OverflowError на первой переполненной ячейке
+	OverflowChecked
+	// OverflowSaturate — насыщение ячейки до math.MaxUint32
+	OverflowSaturate
+)
+
+// String возвращает название политики
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowWrap:
+		return "wrap"
+	case OverflowChecked:
+		return "checked"
+	case OverflowSaturate:
+		return "saturate"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// Ошибки арифметики с контролем переполнения
+var (
+	ErrOverflow      = errors.New("mdm: arithmetic overflow")
+	ErrInvalidPolicy = errors.New("mdm: invalid overflow policy")
+)
+
+// OverflowError сообщает о первой ячейке результата, сумма которой переполнилась
+type OverflowError struct {
+	// Index — линейный индекс ячейки в Data результата
+	Index int
+	// Indices — многомерный индекс ячейки
+	Indices []uint32
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("mdm: arithmetic overflow at result index %d %v", e.Index, e.Indices)
+}
+
+// Unwrap позволяет сопоставлять ошибку с ErrOverflow через errors.Is
+func (e *OverflowError) Unwrap() error {
+	return ErrOverflow
+}
+
+// MultiplyOverflow выполняет последовательное (λ,μ)-умножение uint32-матриц
+// с заданной политикой переполнения
+func MultiplyOverflow(lhs, rhs *Matrix[uint32], lambda, mu uint32, policy OverflowPolicy) (*Matrix[uint32], error) {
+	return multiplyOverflow(lhs, rhs, lambda, mu, policy, false)
+}
+
+// ParallelMultiplyOverflow выполняет параллельное (λ,μ)-умножение uint32-матриц
+// с заданной политикой переполнения
+func ParallelMultiplyOverflow(lhs, rhs *Matrix[uint32], lambda, mu uint32, policy OverflowPolicy) (*Matrix[uint32], error) {
+	return multiplyOverflow(lhs, rhs, lambda, mu, policy, true)
+}
+
+// MultiplyWidened выполняет последовательное (λ,μ)-умножение uint32-матриц
+// с накоплением в uint64. Переполнение uint64 возвращает *OverflowError
+func MultiplyWidened(lhs, rhs *Matrix[uint32], lambda, mu uint32) (*Matrix[uint64], error) {
+	return multiplyWidened(lhs, rhs, lambda, mu, false)
+}
+
+// ParallelMultiplyWidened — параллельный вариант MultiplyWidened
+func ParallelMultiplyWidened(lhs, rhs *Matrix[uint32], lambda, mu uint32) (*Matrix[uint64], error) {
+	return multiplyWidened(lhs, rhs, lambda, mu, true)
+}
+
+func multiplyOverflow(lhs, rhs *Matrix[uint32], lambda, mu uint32, policy OverflowPolicy, parallel bool) (*Matrix[uint32], error) {
+	switch policy {
+	case OverflowWrap:
+		if parallel {
+			return lhs.ParallelMultiplyChecked(lambda, mu, rhs)
+		}
+		return lhs.MultiplyChecked(lambda, mu, rhs)
+	case OverflowChecked, OverflowSaturate:
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPolicy, policy)
+	}
+
+	shape, size, err := validateOperands(lhs, rhs, lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	result := fromShape(shape, make([]uint32, size))
+
+	cell := checkedCell
+	if policy == OverflowSaturate {
+		cell = saturatedCell
+	}
+	err = runOverflowCells(lhs, rhs, lambda, mu, shape, size, parallel, func(c *cellCursor[uint32], idx int) bool {
+		value, ok := cell(c)
+		result.Data[idx] = value
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func multiplyWidened(lhs, rhs *Matrix[uint32], lambda, mu uint32, parallel bool) (*Matrix[uint64], error) {
+	shape, size, err := validateOperands(lhs, rhs, lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	result := fromShape(shape, make([]uint64, size))
+
+	err = runOverflowCells(lhs, rhs, lambda, mu, shape, size, parallel, func(c *cellCursor[uint32], idx int) bool {
+		var sum, carry uint64
+		for k := 0; k < c.terms; k++ {
+			a, b := c.pair()
+			sum, carry = bits.Add64(sum, uint64(a)*uint64(b), 0)
+			if carry != 0 {
+				return false
+			}
+			c.next()
+		}
+		result.Data[idx] = sum
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkedCell вычисляет ячейку; ok == false при переполнении
+func checkedCell(c *cellCursor[uint32]) (uint32, bool) {
+	var sum, carry uint32
+	for k := 0; k < c.terms; k++ {
+		a, b := c.pair()
+		hi, lo := bits.Mul32(a, b)
+		if hi != 0 {
+			return 0, false
+		}
+		sum, carry = bits.Add32(sum, lo, 0)
+		if carry != 0 {
+			return 0, false
+		}
+		c.next()
+	}
+	return sum, true
+}
+
+// saturatedCell вычисляет ячейку с насыщением; всегда успешна
+func saturatedCell(c *cellCursor[uint32]) (uint32, bool) {
+	value, ok := checkedCell(c)
+	if !ok {
+		return math.MaxUint32, true
+	}
+	return value, true
+}
+
+// runOverflowCells обходит ячейки результата и возвращает *OverflowError
+// для ячейки с наименьшим индексом, на которой cell вернула false
+func runOverflowCells(lhs, rhs *Matrix[uint32], lambda, mu uint32, shape []int, size int, parallel bool,
+	cell func(c *cellCursor[uint32], idx int) bool) error {
+	firstOverflow := -1
+	var lock sync.Mutex
+
+	work := func(start, end int) {
+		c := newCellCursor(lhs, rhs, lambda, mu, shape)
+		for idx := start; idx < end; idx++ {
+			c.seek(idx)
+			if !cell(c, idx) {
+				lock.Lock()
+				if firstOverflow < 0 || idx < firstOverflow {
+					firstOverflow = idx
+				}
+				lock.Unlock()
+				return
+			}
+		}
+	}
+
+	if parallel {
+		runChunks(size, work)
+	} else {
+		work(0, size)
+	}
+
+	if firstOverflow >= 0 {
+		return &OverflowError{
+			Index:   firstOverflow,
+			Indices: calculateIndexToArray(shape, firstOverflow),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package mdm
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestOverflowPoliciesWithoutOverflow тестирует, что все политики совпадают с Multiplication без переполнения
+func TestOverflowPoliciesWithoutOverflow(t *testing.T) {
+	lhs := CreateMatrix[uint32](3, 2)
+	lhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	rhs := CreateMatrix[uint32](3, 2)
+	rhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	expected := lhs.Multiplication(0, 1, rhs)
+
+	for _, policy := range []OverflowPolicy{OverflowWrap, OverflowChecked, OverflowSaturate} {
+		t.Run(policy.String(), func(t *testing.T) {
+			sequential, err := MultiplyOverflow(lhs, rhs, 0, 1, policy)
+			if err != nil {
+				t.Fatalf("MultiplyOverflow: %v", err)
+			}
+			parallel, err := ParallelMultiplyOverflow(lhs, rhs, 0, 1, policy)
+			if err != nil {
+				t.Fatalf("ParallelMultiplyOverflow: %v", err)
+			}
+
+			compareMatrices(t, expected, sequential)
+			compareMatrices(t, expected, parallel)
+		})
+	}
+
+	t.Run("widened", func(t *testing.T) {
+		for _, parallel := range []bool{false, true} {
+			var widened *Matrix[uint64]
+			var err error
+			if parallel {
+				widened, err = ParallelMultiplyWidened(lhs, rhs, 0, 1)
+			} else {
+				widened, err = MultiplyWidened(lhs, rhs, 0, 1)
+			}
+			if err != nil {
+				t.Fatalf("widened (parallel=%v): %v", parallel, err)
+			}
+			for i := range expected.Data {
+				if widened.Data[i] != uint64(expected.Data[i]) {
+					t.Errorf("Result mismatch at index %d: got %d, expected %d", i, widened.Data[i], expected.Data[i])
+				}
+			}
+		}
+	})
+}
+
+// TestOverflowPolicies тестирует поведение политик при переполнении
+func TestOverflowPolicies(t *testing.T) {
+	// Ячейка 1: 2^16 * 2^16 переполняет произведение;
+	// ячейка 2: (2^32-1) + 1 переполняет сумму
+	lhs := CreateMatrix[uint32](2, 2)
+	lhs.Data = []uint32{1 << 16, 0, math.MaxUint32, 1}
+
+	rhs := CreateMatrix[uint32](2, 2)
+	rhs.Data = []uint32{1, 1 << 16, 1, 1}
+
+	wrapped := lhs.Multiplication(0, 1, rhs)
+
+	t.Run("checked", func(t *testing.T) {
+		for _, multiply := range []func(lhs, rhs *Matrix[uint32], lambda, mu uint32, policy OverflowPolicy) (*Matrix[uint32], error){
+			MultiplyOverflow, ParallelMultiplyOverflow,
+		} {
+			result, err := multiply(lhs, rhs, 0, 1, OverflowChecked)
+			if !errors.Is(err, ErrOverflow) {
+				t.Fatalf("Expected ErrOverflow, got %v", err)
+			}
+			if result != nil {
+				t.Errorf("Expected nil result on overflow")
+			}
+
+			var overflowErr *OverflowError
+			if !errors.As(err, &overflowErr) {
+				t.Fatalf("Expected *OverflowError, got %T", err)
+			}
+			if overflowErr.Index != 1 {
+				t.Errorf("First overflowing index: got %d, expected 1", overflowErr.Index)
+			}
+			if len(overflowErr.Indices) != 2 || overflowErr.Indices[0] != 0 || overflowErr.Indices[1] != 1 {
+				t.Errorf("First overflowing multi-index: got %v, expected [0 1]", overflowErr.Indices)
+			}
+		}
+	})
+
+	t.Run("saturate", func(t *testing.T) {
+		expected := []uint32{1 << 16, math.MaxUint32, math.MaxUint32, math.MaxUint32}
+		for _, multiply := range []func(lhs, rhs *Matrix[uint32], lambda, mu uint32, policy OverflowPolicy) (*Matrix[uint32], error){
+			MultiplyOverflow, ParallelMultiplyOverflow,
+		} {
+			result, err := multiply(lhs, rhs, 0, 1, OverflowSaturate)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			compareMatrices(t, result, &Matrix[uint32]{X: 2, P: 2, Data: expected})
+		}
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		result, err := ParallelMultiplyOverflow(lhs, rhs, 0, 1, OverflowWrap)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		compareMatrices(t, result, wrapped)
+	})
+
+	t.Run("widened", func(t *testing.T) {
+		expected := []uint64{1 << 16, 1 << 32, 1 << 32, math.MaxUint32<<16 + 1}
+		for _, multiply := range []func(lhs, rhs *Matrix[uint32], lambda, mu uint32) (*Matrix[uint64], error){
+			MultiplyWidened, ParallelMultiplyWidened,
+		} {
+			result, err := multiply(lhs, rhs, 0, 1)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			compareMatrices(t, result, &Matrix[uint64]{X: 2, P: 2, Data: expected})
+		}
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		if _, err := MultiplyOverflow(lhs, rhs, 0, 1, OverflowPolicy(42)); !errors.Is(err, ErrInvalidPolicy) {
+			t.Errorf("Expected ErrInvalidPolicy, got %v", err)
+		}
+	})
+}
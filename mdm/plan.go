@@ -0,0 +1,237 @@
+package mdm
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Plan — заранее вычисленная схема (λ,μ)-умножения для фиксированных форм
+// операндов. План строится один раз, после чего Execute многократно
+// применяет его к матрицам той же формы без повторного вычисления
+// индексных отображений.
+//
+// Индексы операндов группируются в блоки: левая матрица — [l, s, c],
+// правая — [s, c, m], результат — [l, s, m]. Благодаря порядку C каждый блок
+// занимает непрерывный диапазон, и смещения вычисляются через целые страйды.
+type Plan[T Number] struct {
+	lhsShape, rhsShape, resultShape []int
+	lambda, mu                      uint32
+
+	// Число элементов операндов и результата
+	lhsSize, rhsSize, size int
+
+	// Размеры блоков левых свободных (l), скоттовых (s), кэлиевых (c)
+	// и правых свободных (m) индексов
+	lSize, sSize, cSize, mSize int
+
+	// Страйды блоков: строка [l, s] левой матрицы, индексы s и c правой
+	lhsRowStride int
+	rhsSStride   int
+	rhsCStride   int
+
+	// rhsTerms[k] — смещение k-го слагаемого внутри блока s правой матрицы
+	rhsTerms []int
+
+	// Стратегия вычисления; StrategyAuto разрешается при построении плана
+	strategy Strategy
+}
+
+// NewPlan строит план (λ,μ)-умножения кубических матриц размерности X
+// порядков lhsP и rhsP. Порядки больше 64 дают ErrInvalidShape до
+// построения форм операндов
+func NewPlan[T Number](x, lhsP, rhsP, lambda, mu uint32) (*Plan[T], error) {
+	if _, err := checkCubic(x, lhsP); err != nil {
+		return nil, fmt.Errorf("lhs: %w", err)
+	}
+	if _, err := checkCubic(x, rhsP); err != nil {
+		return nil, fmt.Errorf("rhs: %w", err)
+	}
+	return NewShapedPlan[T](cubicShape(x, lhsP), cubicShape(x, rhsP), lambda, mu)
+}
+
+// NewShapedPlan строит план (λ,μ)-умножения матриц с произвольными длинами осей.
+// Скоттовы и кэлиевы оси операндов должны совпадать
+func NewShapedPlan[T Number](lhsShape, rhsShape []int, lambda, mu uint32) (*Plan[T], error) {
+	lhsSize, err := checkShape(lhsShape)
+	if err != nil {
+		return nil, fmt.Errorf("lhs: %w", err)
+	}
+	rhsSize, err := checkShape(rhsShape)
+	if err != nil {
+		return nil, fmt.Errorf("rhs: %w", err)
+	}
+	shape, size, err := resultShape(lhsShape, rhsShape, lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+
+	// Размеры блоков не превосходят размеров операндов, переполнение исключено
+	l := len(lhsShape) - int(lambda+mu)
+	lSize, _ := shapeSize(lhsShape[:l])
+	sSize, _ := shapeSize(rhsShape[:lambda])
+	cSize, _ := shapeSize(rhsShape[lambda : lambda+mu])
+	mSize, _ := shapeSize(rhsShape[lambda+mu:])
+
+	p := &Plan[T]{
+		lhsShape:     slices.Clone(lhsShape),
+		rhsShape:     slices.Clone(rhsShape),
+		resultShape:  shape,
+		lambda:       lambda,
+		mu:           mu,
+		lhsSize:      lhsSize,
+		rhsSize:      rhsSize,
+		size:         size,
+		lSize:        lSize,
+		sSize:        sSize,
+		cSize:        cSize,
+		mSize:        mSize,
+		lhsRowStride: cSize,
+		rhsSStride:   cSize * mSize,
+		rhsCStride:   mSize,
+		rhsTerms:     make([]int, cSize),
+		strategy:     autoStrategy(mSize),
+	}
+	for k := range p.rhsTerms {
+		p.rhsTerms[k] = k * p.rhsCStride
+	}
+	return p, nil
+}
+
+// ResultP возвращает порядок результирующей матрицы
+func (p *Plan[T]) ResultP() uint32 {
+	return uint32(len(p.resultShape))
+}
+
+// ResultShape возвращает длины осей результирующей матрицы
+func (p *Plan[T]) ResultShape() []int {
+	return slices.Clone(p.resultShape)
+}
+
+// Size возвращает число элементов результирующей матрицы
+func (p *Plan[T]) Size() int {
+	return p.size
+}
+
+// Strategy возвращает стратегию вычисления плана
+func (p *Plan[T]) Strategy() Strategy {
+	return p.strategy
+}
+
+// SetStrategy задаёт стратегию вычисления; StrategyAuto выбирает её по размерам
+// блоков. Не должна вызываться одновременно с Execute
+func (p *Plan[T]) SetStrategy(strategy Strategy) error {
+	switch strategy {
+	case StrategyAuto:
+		strategy = autoStrategy(p.mSize)
+	case StrategyDirect, StrategyGEMM, StrategyOdometer:
+	default:
+		return fmt.Errorf("%w: %v", ErrInvalidStrategy, strategy)
+	}
+	p.strategy = strategy
+	return nil
+}
+
+// NewResult создаёт нулевую матрицу формы результата
+func (p *Plan[T]) NewResult() *Matrix[T] {
+	return fromShape(p.resultShape, make([]T, p.size))
+}
+
+// Execute вычисляет (λ,μ)-произведение lhs и rhs в out.
+// Формы всех матриц должны совпадать с формами, заданными при построении плана
+func (p *Plan[T]) Execute(lhs, rhs, out *Matrix[T]) error {
+	if err := p.check(lhs, rhs, out); err != nil {
+		return err
+	}
+	p.run(lhs, rhs, out, false)
+	return nil
+}
+
+// ParallelExecute — параллельный вариант Execute
+func (p *Plan[T]) ParallelExecute(lhs, rhs, out *Matrix[T]) error {
+	if err := p.check(lhs, rhs, out); err != nil {
+		return err
+	}
+	p.run(lhs, rhs, out, true)
+	return nil
+}
+
+// run выполняет план выбранной стратегией для проверенных матриц
+func (p *Plan[T]) run(lhs, rhs, out *Matrix[T], parallel bool) {
+	switch p.strategy {
+	case StrategyOdometer:
+		if parallel {
+			lhs.parallelMultiplication(p.lambda, p.mu, rhs, out)
+		} else {
+			lhs.multiplication(p.lambda, p.mu, rhs, out)
+		}
+	case StrategyGEMM:
+		if parallel {
+			runChunks(p.gemmTasks(), func(start, end int) {
+				p.gemmRange(lhs.Data, rhs.Data, out.Data, start, end)
+			})
+		} else {
+			p.gemmRange(lhs.Data, rhs.Data, out.Data, 0, p.gemmTasks())
+		}
+	default:
+		if parallel {
+			runChunks(p.size, func(start, end int) {
+				p.executeRange(lhs.Data, rhs.Data, out.Data, start, end)
+			})
+		} else {
+			p.executeRange(lhs.Data, rhs.Data, out.Data, 0, p.size)
+		}
+	}
+}
+
+// check проверяет соответствие матриц плану
+func (p *Plan[T]) check(lhs, rhs, out *Matrix[T]) error {
+	operands := []struct {
+		name  string
+		m     *Matrix[T]
+		shape []int
+		size  int
+	}{
+		{"lhs", lhs, p.lhsShape, p.lhsSize},
+		{"rhs", rhs, p.rhsShape, p.rhsSize},
+		{"out", out, p.resultShape, p.size},
+	}
+	for _, op := range operands {
+		if op.m == nil {
+			return fmt.Errorf("%s: %w", op.name, ErrNilMatrix)
+		}
+		if !op.m.hasShape(op.shape) {
+			return fmt.Errorf("%s: %w: got shape %v, plan expects %v",
+				op.name, ErrDimensionMismatch, op.m.shape(), op.shape)
+		}
+		if len(op.m.Data) != op.size {
+			return fmt.Errorf("%s: %w: got %d, want %d", op.name, ErrDataLength, len(op.m.Data), op.size)
+		}
+	}
+	return nil
+}
+
+// executeRange вычисляет ячейки результата [start, end)
+func (p *Plan[T]) executeRange(lhs, rhs, out []T, start, end int) {
+	if start >= end {
+		return
+	}
+	// Ячейка результата idx = row*mSize + mi, где row — номер строки [l, s]
+	row, mi := start/p.mSize, start%p.mSize
+	for idx := start; idx < end; idx++ {
+		si := row % p.sSize
+		lhsBase := row * p.lhsRowStride
+		rhsBase := si*p.rhsSStride + mi
+
+		var tempValue T
+		for k, offset := range p.rhsTerms {
+			tempValue += lhs[lhsBase+k] * rhs[rhsBase+offset]
+		}
+		out[idx] = tempValue
+
+		mi++
+		if mi == p.mSize {
+			mi = 0
+			row++
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestPlanMatchesMultiplication тестирует совпадение плана с Multiplication для всех допустимых λ и μ
+func TestPlanMatchesMultiplication(t *testing.T) {
+	X := uint32(3)
+
+	for lhsP := uint32(1); lhsP <= 4; lhsP++ {
+		for rhsP := uint32(1); rhsP <= 4; rhsP++ {
+			lhs := CreateMatrix[int64](X, lhsP)
+			for i := range lhs.Data {
+				lhs.Data[i] = int64(i%7) - 3
+			}
+			rhs := CreateMatrix[int64](X, rhsP)
+			for i := range rhs.Data {
+				rhs.Data[i] = int64((i+2)%5) - 1
+			}
+
+			for lambda := uint32(0); lambda <= min(lhsP, rhsP); lambda++ {
+				for mu := uint32(0); lambda+mu <= min(lhsP, rhsP); mu++ {
+					name := fmt.Sprintf("lhsP=%d_rhsP=%d_lambda=%d_mu=%d", lhsP, rhsP, lambda, mu)
+					t.Run(name, func(t *testing.T) {
+						plan, err := NewPlan[int64](X, lhsP, rhsP, lambda, mu)
+						if err != nil {
+							t.Fatalf("NewPlan: %v", err)
+						}
+
+						expected := lhs.Multiplication(lambda, mu, rhs)
+						compareMatrices(t, referenceMultiplication(lhs, rhs, lambda, mu), expected)
+
+						out := plan.NewResult()
+						if err := plan.Execute(lhs, rhs, out); err != nil {
+							t.Fatalf("Execute: %v", err)
+						}
+						compareMatrices(t, expected, out)
+
+						parallelOut := plan.NewResult()
+						if err := plan.ParallelExecute(lhs, rhs, parallelOut); err != nil {
+							t.Fatalf("ParallelExecute: %v", err)
+						}
+						compareMatrices(t, expected, parallelOut)
+					})
+				}
+			}
+		}
+	}
+}
+
+// TestPlanReuse тестирует многократное применение одного плана
+func TestPlanReuse(t *testing.T) {
+	plan, err := NewPlan[uint32](3, 2, 2, 1, 1)
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	out := plan.NewResult()
+
+	for step := uint32(0); step < 10; step++ {
+		lhs := CreateMatrix[uint32](3, 2)
+		rhs := CreateMatrix[uint32](3, 2)
+		for i := range lhs.Data {
+			lhs.Data[i] = uint32(i) + step
+			rhs.Data[i] = uint32(i) * step
+		}
+
+		if err := plan.Execute(lhs, rhs, out); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		compareMatrices(t, lhs.Multiplication(1, 1, rhs), out)
+	}
+}
+
+// TestScottIndexMapping тестирует отображение скоттовых индексов при разном числе свободных индексов операндов
+func TestScottIndexMapping(t *testing.T) {
+	// Результат [l1, l2, s, m] = A[l1, l2, s] * B[s, m]
+	lhs := CreateMatrix[uint32](2, 3)
+	lhs.Data = []uint32{1, 2, 3, 4, 5, 6, 7, 8}
+
+	rhs := CreateMatrix[uint32](2, 2)
+	rhs.Data = []uint32{1, 2, 3, 4}
+
+	expected := &Matrix[uint32]{X: 2, P: 4, Data: []uint32{1, 2, 6, 8, 3, 6, 12, 16, 5, 10, 18, 24, 7, 14, 24, 32}}
+
+	compareMatrices(t, expected, lhs.Multiplication(1, 0, rhs))
+	compareMatrices(t, expected, lhs.ParallelMultiplication(1, 0, rhs))
+}
+
+// TestPlanErrors тестирует проверку форм при построении и применении плана
+func TestPlanErrors(t *testing.T) {
+	if _, err := NewPlan[uint32](3, 1, 2, 1, 1); !errors.Is(err, ErrInvalidLambdaMu) {
+		t.Errorf("NewPlan error = %v, expected ErrInvalidLambdaMu", err)
+	}
+	if _, err := NewPlan[uint32](1<<16, 4, 1, 0, 0); !errors.Is(err, ErrSizeOverflow) {
+		t.Errorf("NewPlan error = %v, expected ErrSizeOverflow", err)
+	}
+
+	plan, err := NewPlan[uint32](3, 2, 2, 0, 1)
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+
+	lhs := CreateMatrix[uint32](3, 2)
+	rhs := CreateMatrix[uint32](3, 2)
+
+	tests := []struct {
+		name          string
+		lhs, rhs, out *Matrix[uint32]
+		expected      error
+	}{
+		{"nil out", lhs, rhs, nil, ErrNilMatrix},
+		{"wrong lhs X", CreateMatrix[uint32](2, 2), rhs, plan.NewResult(), ErrDimensionMismatch},
+		{"wrong rhs P", lhs, CreateMatrix[uint32](3, 3), plan.NewResult(), ErrDimensionMismatch},
+		{"short out data", lhs, rhs, &Matrix[uint32]{X: 3, P: 2, Data: make([]uint32, 3)}, ErrDataLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := plan.Execute(tt.lhs, tt.rhs, tt.out); !errors.Is(err, tt.expected) {
+				t.Errorf("Execute error = %v, expected %v", err, tt.expected)
+			}
+			if err := plan.ParallelExecute(tt.lhs, tt.rhs, tt.out); !errors.Is(err, tt.expected) {
+				t.Errorf("ParallelExecute error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+// BenchmarkPlanExecute сравнивает повторное применение плана с Multiplication
+func BenchmarkPlanExecute(b *testing.B) {
+	lhs := CreateMatrix[uint32](10, 4)
+	for i := range lhs.Data {
+		lhs.Data[i] = uint32(i % 5)
+	}
+	rhs := CreateMatrix[uint32](10, 4)
+	for i := range rhs.Data {
+		rhs.Data[i] = uint32((i + 2) % 5)
+	}
+
+	plan, err := NewPlan[uint32](10, 4, 4, 1, 2)
+	if err != nil {
+		b.Fatalf("NewPlan: %v", err)
+	}
+	out := plan.NewResult()
+
+	b.Run("Plan", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = plan.Execute(lhs, rhs, out)
+		}
+	})
+	b.Run("Multiplication", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			lhs.Multiplication(1, 2, rhs)
+		}
+	})
+}
+
+// referenceMultiplication вычисляет (λ,μ)-произведение непосредственно по определению
+// c[l, s, m] = Σ_c a[l, s, c] * b[s, c, m]
+func referenceMultiplication[T Number](lhs, rhs *Matrix[T], lambda, mu uint32) *Matrix[T] {
+	return referenceSemiring[T](PlusTimes[T]{}, lhs, rhs, lambda, mu)
+}
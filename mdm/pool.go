@@ -0,0 +1,205 @@
+package mdm
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrPoolClosed возвращается при выполнении умножения на закрытом пуле
+var ErrPoolClosed = errors.New("mdm: worker pool is closed")
+
+// Options задаёт параметры параллельного выполнения. Нулевое значение
+// и nil означают параметры по умолчанию
+type Options struct {
+	// Workers — число одновременно работающих исполнителей;
+	// 0 — runtime.NumCPU() или размер Pool
+	Workers int
+
+	// ChunkSize — число ячеек результата в одной порции работы, которую
+	// исполнитель берёт за раз; между порциями проверяется отмена контекста.
+	// 0 — подбирается по размеру задачи
+	ChunkSize int
+
+	// Pool — общий пул горутин; nil — горутины запускаются на время вызова
+	Pool *Pool
+
+	// Progress, если задан, вызывается с числом вычисленных и общим числом
+	// ячеек результата не чаще раза в ProgressInterval, а также один раз
+	// по успешном завершении с done == total. Вызовы не пересекаются во
+	// времени, но могут происходить из разных горутин. О ходе выполнения
+	// сообщают только методы, принимающие Options: MultiplyWith,
+	// ParallelMultiplyWith, ExecuteWith и ParallelExecuteWith
+	Progress func(done, total int)
+
+	// ProgressInterval — минимальный интервал между вызовами Progress;
+	// 0 — defaultProgressInterval
+	ProgressInterval time.Duration
+}
+
+// defaultProgressInterval — интервал между вызовами Progress по умолчанию
+const defaultProgressInterval = 100 * time.Millisecond
+
+// workers возвращает число исполнителей
+func (o *Options) workers() int {
+	var workers int
+	if o != nil {
+		workers = o.Workers
+		if o.Pool != nil && (workers <= 0 || workers > o.Pool.workers) {
+			workers = o.Pool.workers
+		}
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return workers
+}
+
+// chunk возвращает размер порции в единицах работы
+func (o *Options) chunk(size, workers, unitCells, unitCost int) int {
+	if o != nil && o.ChunkSize > 0 {
+		return max(1, o.ChunkSize/max(unitCells, 1))
+	}
+	chunk := (size + workers*chunksPerWorker - 1) / (workers * chunksPerWorker)
+	if unitCost > 0 {
+		chunk = min(chunk, cancelWork/unitCost)
+	}
+	return max(chunk, 1)
+}
+
+// spawn запускает task в пуле или в новой горутине. Ожидание свободной
+// горутины пула прерывается отменой ctx
+func (o *Options) spawn(ctx context.Context, task func()) error {
+	if o == nil || o.Pool == nil {
+		go task()
+		return nil
+	}
+	return o.Pool.submit(ctx, task)
+}
+
+// progress накапливает число вычисленных ячеек и вызывает Options.Progress
+// с ограничением частоты; nil означает отсутствие обратного вызова
+type progress struct {
+	report   func(done, total int)
+	interval time.Duration
+	total    int
+	done     atomic.Int64
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// newProgress создаёт счётчик прогресса для total ячеек
+func (o *Options) newProgress(total int) *progress {
+	if o == nil || o.Progress == nil {
+		return nil
+	}
+	interval := o.ProgressInterval
+	if interval <= 0 {
+		interval = defaultProgressInterval
+	}
+	return &progress{report: o.Progress, interval: interval, total: total, last: time.Now()}
+}
+
+// add учитывает cells вычисленных ячеек и вызывает обратный вызов, если с
+// прошлого вызова прошло не меньше интервала. Занятый обратный вызов не
+// задерживает исполнителей: отчёт пропускается
+func (p *progress) add(cells int) {
+	if p == nil {
+		return
+	}
+	p.done.Add(int64(cells))
+	if !p.mu.TryLock() {
+		return
+	}
+	defer p.mu.Unlock()
+	if now := time.Now(); now.Sub(p.last) >= p.interval {
+		p.last = now
+		p.report(int(p.done.Load()), p.total)
+	}
+}
+
+// finish сообщает о завершении всей работы
+func (p *progress) finish() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.report(p.total, p.total)
+}
+
+// Pool — долгоживущий пул горутин, который можно разделять между
+// умножениями через Options.Pool. Пул ограничивает суммарное число
+// исполнителей всех одновременных умножений и избавляет от запуска
+// горутин на каждый вызов. Пул безопасен для одновременного использования
+type Pool struct {
+	tasks   chan func()
+	done    chan struct{}
+	workers int
+	wg      sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewPool запускает пул из workers горутин; workers <= 0 означает runtime.NumCPU()
+func NewPool(workers int) *Pool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	p := &Pool{tasks: make(chan func()), done: make(chan struct{}), workers: workers}
+	p.wg.Add(workers)
+	for range workers {
+		go func() {
+			defer p.wg.Done()
+			for {
+				select {
+				case task := <-p.tasks:
+					task()
+				case <-p.done:
+					return
+				}
+			}
+		}()
+	}
+	return p
+}
+
+// Workers возвращает число горутин пула
+func (p *Pool) Workers() int {
+	return p.workers
+}
+
+// Close дожидается завершения начатых задач и останавливает горутины пула.
+// Повторный вызов безопасен; умножения на закрытом пуле возвращают ErrPoolClosed
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// submit передаёт задачу свободной горутине пула, ожидая её освобождения.
+// Ожидание прерывается отменой ctx, возвращающей ctx.Err(), и закрытием пула
+func (p *Pool) submit(ctx context.Context, task func()) error {
+	select {
+	case <-p.done:
+		return ErrPoolClosed
+	default:
+	}
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-p.done:
+		return ErrPoolClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,141 @@
+package mdm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestSchedule тестирует, что перехват работы обрабатывает каждую единицу ровно один раз
+func TestSchedule(t *testing.T) {
+	for _, size := range []int{1, 7, 100, 1013} {
+		for _, opts := range []*Options{nil, {Workers: 1}, {Workers: 3, ChunkSize: 1}, {Workers: 16, ChunkSize: 5}} {
+			t.Run(fmt.Sprintf("size=%d_%+v", size, opts), func(t *testing.T) {
+				counts := make([]atomic.Int32, size)
+				err := opts.schedule(context.Background(), cellWorkload(size, 0, func(start, end int) {
+					for i := start; i < end; i++ {
+						counts[i].Add(1)
+					}
+				}), true)
+				if err != nil {
+					t.Fatalf("schedule: %v", err)
+				}
+				for i := range counts {
+					if n := counts[i].Load(); n != 1 {
+						t.Fatalf("Unit %d processed %d times", i, n)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestMultiplyOptions тестирует совпадение результатов при разных параметрах выполнения
+func TestMultiplyOptions(t *testing.T) {
+	lhs := CreateMatrix[int64](4, 4)
+	rhs := CreateMatrix[int64](4, 4)
+	for i := range lhs.Data {
+		lhs.Data[i] = int64(i%7) - 3
+		rhs.Data[i] = int64(i%5) - 2
+	}
+	expected := lhs.Multiplication(1, 2, rhs)
+
+	pool := NewPool(3)
+	defer pool.Close()
+
+	for _, opts := range []*Options{
+		{Workers: 1},
+		{Workers: 5, ChunkSize: 1},
+		{ChunkSize: 1000},
+		{Pool: pool},
+		{Pool: pool, Workers: 2, ChunkSize: 3},
+	} {
+		t.Run(fmt.Sprintf("%+v", *opts), func(t *testing.T) {
+			for _, strategy := range []Strategy{StrategyDirect, StrategyGEMM, StrategyOdometer} {
+				plan, err := NewPlan[int64](4, 4, 4, 1, 2)
+				if err != nil {
+					t.Fatalf("NewPlan: %v", err)
+				}
+				_ = plan.SetStrategy(strategy)
+				out := plan.NewResult()
+				if err := plan.ParallelExecuteWith(context.Background(), lhs, rhs, out, opts); err != nil {
+					t.Fatalf("%v: ParallelExecuteWith: %v", strategy, err)
+				}
+				compareMatrices(t, expected, out)
+			}
+		})
+	}
+}
+
+// TestSharedPool тестирует одновременные умножения на общем пуле
+func TestSharedPool(t *testing.T) {
+	pool := NewPool(2)
+	lhs := CreateMatrix[float64](3, 4)
+	for i := range lhs.Data {
+		lhs.Data[i] = float64(i % 11)
+	}
+	expected := lhs.Multiplication(0, 2, lhs)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	results := make([]*Matrix[float64], len(errs))
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = lhs.ParallelMultiplyWith(context.Background(), 0, 2, lhs, &Options{Pool: pool, ChunkSize: 4})
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Call %d: %v", i, err)
+		}
+		compareMatrices(t, expected, results[i])
+	}
+
+	pool.Close()
+	pool.Close()
+	if _, err := lhs.ParallelMultiplyWith(context.Background(), 0, 2, lhs, &Options{Pool: pool}); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Closed pool: expected ErrPoolClosed, got %v", err)
+	}
+}
+
+// TestBusyPool тестирует, что ожидание занятого пула прерывается отменой
+// контекста и не мешает его закрытию
+func TestBusyPool(t *testing.T) {
+	pool := NewPool(1)
+	release := make(chan struct{})
+	if err := pool.submit(context.Background(), func() { <-release }); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	lhs := CreateMatrix[int64](3, 2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := lhs.ParallelMultiplyWith(ctx, 0, 1, lhs, &Options{Pool: pool}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Busy pool: expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// Ожидающая отправка не должна блокировать Close
+	submitted := make(chan error)
+	go func() { submitted <- pool.submit(context.Background(), func() {}) }()
+	closed := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(closed)
+	}()
+	close(release)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close blocked by a pending submit")
+	}
+	if err := <-submitted; err != nil && !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Pending submit: expected nil or ErrPoolClosed, got %v", err)
+	}
+}
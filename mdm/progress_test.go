@@ -0,0 +1,101 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// progressRecorder запоминает вызовы Options.Progress
+type progressRecorder struct {
+	mu    sync.Mutex
+	calls [][2]int
+}
+
+func (r *progressRecorder) report(done, total int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, [2]int{done, total})
+}
+
+// check проверяет, что done не убывает, total постоянен, а последний вызов
+// сообщает о завершении
+func (r *progressRecorder) check(t *testing.T, total int) {
+	t.Helper()
+	if len(r.calls) == 0 {
+		t.Fatal("Progress was not called")
+	}
+	prev := 0
+	for _, call := range r.calls {
+		if call[1] != total || call[0] < prev || call[0] > total {
+			t.Fatalf("Unexpected progress calls %v for total %d", r.calls, total)
+		}
+		prev = call[0]
+	}
+	if last := r.calls[len(r.calls)-1]; last[0] != total {
+		t.Errorf("Last progress call %v, expected done == total", last)
+	}
+}
+
+// TestProgress тестирует отчёты о ходе выполнения для всех стратегий
+func TestProgress(t *testing.T) {
+	lhs := filledShaped(t, 5, 40, 3, 6)
+	rhs := filledShaped(t, 3, 3, 6, 5)
+	expected := lhs.Multiplication(1, 1, rhs)
+
+	for _, strategy := range []Strategy{StrategyDirect, StrategyGEMM, StrategyOdometer} {
+		for _, parallel := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%v_parallel=%v", strategy, parallel), func(t *testing.T) {
+				plan, err := NewShapedPlan[int64](lhs.Shape(), rhs.Shape(), 1, 1)
+				if err != nil {
+					t.Fatalf("NewShapedPlan: %v", err)
+				}
+				_ = plan.SetStrategy(strategy)
+				var rec progressRecorder
+				opts := &Options{Workers: 3, ChunkSize: 7, Progress: rec.report, ProgressInterval: time.Nanosecond}
+				out := plan.NewResult()
+				if parallel {
+					err = plan.ParallelExecuteWith(context.Background(), lhs, rhs, out, opts)
+				} else {
+					err = plan.ExecuteWith(context.Background(), lhs, rhs, out, opts)
+				}
+				if err != nil {
+					t.Fatalf("Execute: %v", err)
+				}
+				compareMatrices(t, expected, out)
+				rec.check(t, len(out.Data))
+				if len(rec.calls) < 2 {
+					t.Errorf("Expected intermediate progress calls, got %v", rec.calls)
+				}
+			})
+		}
+	}
+}
+
+// TestProgressInterval тестирует ограничение частоты вызовов
+func TestProgressInterval(t *testing.T) {
+	lhs := filledShaped(t, 5, 4, 4, 4, 4)
+	var rec progressRecorder
+	opts := &Options{ChunkSize: 1, Progress: rec.report, ProgressInterval: time.Hour}
+	result, err := lhs.MultiplyWith(context.Background(), 1, 2, lhs, opts)
+	if err != nil {
+		t.Fatalf("MultiplyWith: %v", err)
+	}
+	rec.check(t, len(result.Data))
+	if len(rec.calls) != 1 {
+		t.Errorf("Expected only the final call, got %v", rec.calls)
+	}
+
+	// При отмене финальный вызов не выполняется
+	rec.calls = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := lhs.ParallelMultiplyWith(ctx, 1, 2, lhs, opts); err == nil {
+		t.Fatal("Expected cancellation error")
+	}
+	if len(rec.calls) != 0 {
+		t.Errorf("Unexpected progress calls after cancellation: %v", rec.calls)
+	}
+}
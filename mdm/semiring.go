@@ -0,0 +1,206 @@
+package mdm
+
+import (
+	"math"
+	"reflect"
+)
+
+// Real объединяет упорядоченные типы элементов, для которых определены
+// полукольца с операциями min и max
+type Real interface {
+	~int8 | ~int16 | ~int32 | ~int64 |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Semiring задаёт операции, по которым вычисляется (λ,μ)-произведение:
+// c[l, s, m] = Add по c от Mul(a[l, s, c], b[s, c, m]), начиная с Zero.
+// Zero должен быть нейтральным элементом Add
+type Semiring[T Number] interface {
+	Zero() T
+	Add(a, b T) T
+	Mul(a, b T) T
+}
+
+// PlusTimes — обычное полукольцо (+, ×); совпадает с Multiplication
+type PlusTimes[T Number] struct{}
+
+func (PlusTimes[T]) Zero() T      { return 0 }
+func (PlusTimes[T]) Add(a, b T) T { return a + b }
+func (PlusTimes[T]) Mul(a, b T) T { return a * b }
+
+// MinPlus — тропическое полукольцо (min, +) для кратчайших путей.
+// Нулём служит максимальное значение типа (+Inf для вещественных),
+// которое поглощает сложение и обозначает отсутствие ребра. Сумма конечных
+// целых весов не должна переполнять тип
+type MinPlus[T Real] struct{}
+
+func (MinPlus[T]) Zero() T      { return maxValue[T]() }
+func (MinPlus[T]) Add(a, b T) T { return min(a, b) }
+func (MinPlus[T]) Mul(a, b T) T {
+	if inf := maxValue[T](); a == inf || b == inf {
+		return inf
+	}
+	return a + b
+}
+
+// MaxTimes — полукольцо (max, ×) над неотрицательными значениями,
+// например для наиболее вероятных путей
+type MaxTimes[T Real] struct{}
+
+func (MaxTimes[T]) Zero() T      { return 0 }
+func (MaxTimes[T]) Add(a, b T) T { return max(a, b) }
+func (MaxTimes[T]) Mul(a, b T) T { return a * b }
+
+// Boolean — булево полукольцо (OR, AND) для достижимости.
+// Любое ненулевое значение считается истиной; результат равен 0 или 1
+type Boolean[T Number] struct{}
+
+func (Boolean[T]) Zero() T { return 0 }
+func (Boolean[T]) Add(a, b T) T {
+	if a != 0 || b != 0 {
+		return 1
+	}
+	return 0
+}
+func (Boolean[T]) Mul(a, b T) T {
+	if a != 0 && b != 0 {
+		return 1
+	}
+	return 0
+}
+
+// MaxMin — полукольцо (max, min) для путей с наибольшей пропускной способностью.
+// Нулём служит минимальное значение типа (-Inf для вещественных)
+type MaxMin[T Real] struct{}
+
+func (MaxMin[T]) Zero() T      { return minValue[T]() }
+func (MaxMin[T]) Add(a, b T) T { return max(a, b) }
+func (MaxMin[T]) Mul(a, b T) T { return min(a, b) }
+
+// dotter — необязательное расширение Semiring, вычисляющее свёртку ячейки
+// целиком без вызова Add и Mul через интерфейс для каждого слагаемого
+type dotter[T Number] interface {
+	dot(lhs, rhs []T, lhsBase, rhsBase int, offsets []int) T
+}
+
+// MultiplySemiring выполняет (λ,μ)-умножение над полукольцом sr
+func (m *Matrix[T]) MultiplySemiring(sr Semiring[T], lambda, mu uint32, other *Matrix[T]) (*Matrix[T], error) {
+	return m.multiplyPlanned(sr, lambda, mu, other, false)
+}
+
+// ParallelMultiplySemiring выполняет параллельное (λ,μ)-умножение над полукольцом sr
+func (m *Matrix[T]) ParallelMultiplySemiring(sr Semiring[T], lambda, mu uint32, other *Matrix[T]) (*Matrix[T], error) {
+	return m.multiplyPlanned(sr, lambda, mu, other, true)
+}
+
+// ExecuteSemiring вычисляет (λ,μ)-произведение по плану над полукольцом sr
+func (p *Plan[T]) ExecuteSemiring(sr Semiring[T], lhs, rhs, out *Matrix[T]) error {
+	if err := p.check(lhs, rhs, out); err != nil {
+		return err
+	}
+	p.runSemiring(sr, lhs, rhs, out, false)
+	return nil
+}
+
+// ParallelExecuteSemiring — параллельный вариант ExecuteSemiring
+func (p *Plan[T]) ParallelExecuteSemiring(sr Semiring[T], lhs, rhs, out *Matrix[T]) error {
+	if err := p.check(lhs, rhs, out); err != nil {
+		return err
+	}
+	p.runSemiring(sr, lhs, rhs, out, true)
+	return nil
+}
+
+// runSemiring выполняет план над полукольцом; для nil и PlusTimes
+// используются специализированные ядра выбранной стратегии
+func (p *Plan[T]) runSemiring(sr Semiring[T], lhs, rhs, out *Matrix[T], parallel bool) {
+	if _, ok := sr.(PlusTimes[T]); ok || sr == nil {
+		p.run(lhs, rhs, out, parallel)
+		return
+	}
+	if parallel {
+		runChunks(p.size, func(start, end int) {
+			p.executeRangeSemiring(sr, lhs.Data, rhs.Data, out.Data, start, end)
+		})
+	} else {
+		p.executeRangeSemiring(sr, lhs.Data, rhs.Data, out.Data, 0, p.size)
+	}
+}
+
+// executeRangeSemiring вычисляет ячейки результата [start, end) над полукольцом
+func (p *Plan[T]) executeRangeSemiring(sr Semiring[T], lhs, rhs, out []T, start, end int) {
+	if start >= end {
+		return
+	}
+	d, isDotter := sr.(dotter[T])
+	row, mi := start/p.mSize, start%p.mSize
+	for idx := start; idx < end; idx++ {
+		si := row % p.sSize
+		lhsBase := row * p.lhsRowStride
+		rhsBase := si*p.rhsSStride + mi
+
+		if isDotter {
+			out[idx] = d.dot(lhs, rhs, lhsBase, rhsBase, p.rhsTerms)
+		} else {
+			tempValue := sr.Zero()
+			for k, offset := range p.rhsTerms {
+				tempValue = sr.Add(tempValue, sr.Mul(lhs[lhsBase+k], rhs[rhsBase+offset]))
+			}
+			out[idx] = tempValue
+		}
+
+		mi++
+		if mi == p.mSize {
+			mi = 0
+			row++
+		}
+	}
+}
+
+// maxValue возвращает наибольшее значение типа T (+Inf для вещественных)
+func maxValue[T Real]() T {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int8:
+		return convert[T](int64(math.MaxInt8))
+	case reflect.Int16:
+		return convert[T](int64(math.MaxInt16))
+	case reflect.Int32:
+		return convert[T](int64(math.MaxInt32))
+	case reflect.Int64:
+		return convert[T](int64(math.MaxInt64))
+	case reflect.Uint8:
+		return convert[T](uint64(math.MaxUint8))
+	case reflect.Uint16:
+		return convert[T](uint64(math.MaxUint16))
+	case reflect.Uint32:
+		return convert[T](uint64(math.MaxUint32))
+	case reflect.Uint64:
+		return convert[T](uint64(math.MaxUint64))
+	default:
+		return T(math.Inf(1))
+	}
+}
+
+// convert выполняет неконстантное преобразование целого значения к T
+func convert[T Real, V int64 | uint64](v V) T {
+	return T(v)
+}
+
+// minValue возвращает наименьшее значение типа T (-Inf для вещественных)
+func minValue[T Real]() T {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int8:
+		return convert[T](int64(math.MinInt8))
+	case reflect.Int16:
+		return convert[T](int64(math.MinInt16))
+	case reflect.Int32:
+		return convert[T](int64(math.MinInt32))
+	case reflect.Int64:
+		return convert[T](int64(math.MinInt64))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return 0
+	default:
+		return T(math.Inf(-1))
+	}
+}
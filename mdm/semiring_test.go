@@ -0,0 +1,207 @@
+package mdm
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestPlusTimesSemiring тестирует совпадение обычного полукольца с Multiplication
+func TestPlusTimesSemiring(t *testing.T) {
+	lhs := CreateMatrix[int32](3, 3)
+	rhs := CreateMatrix[int32](3, 3)
+	for i := range lhs.Data {
+		lhs.Data[i] = int32(i%5) - 2
+		rhs.Data[i] = int32(i%7) - 3
+	}
+
+	for _, sr := range []Semiring[int32]{nil, PlusTimes[int32]{}, semiringFunc[int32]{PlusTimes[int32]{}}} {
+		t.Run(fmt.Sprintf("%T", sr), func(t *testing.T) {
+			result, err := lhs.MultiplySemiring(sr, 1, 1, rhs)
+			if err != nil {
+				t.Fatalf("MultiplySemiring: %v", err)
+			}
+			compareMatrices(t, lhs.Multiplication(1, 1, rhs), result)
+		})
+	}
+}
+
+// TestGraphSemirings тестирует встроенные полукольца на задачах о путях в графе
+func TestGraphSemirings(t *testing.T) {
+	inf := math.Inf(1)
+
+	// Ориентированный граф 0 -> 1 -> 2 -> 3 и ребро 0 -> 2
+	weights := CreateMatrix[float64](4, 2)
+	weights.Data = []float64{
+		0, 1, 5, inf,
+		inf, 0, 2, inf,
+		inf, inf, 0, 1,
+		inf, inf, inf, 0,
+	}
+
+	t.Run("min-plus shortest paths", func(t *testing.T) {
+		paths := weights
+		for step := 0; step < 2; step++ {
+			var err error
+			paths, err = paths.ParallelMultiplySemiring(MinPlus[float64]{}, 0, 1, paths)
+			if err != nil {
+				t.Fatalf("ParallelMultiplySemiring: %v", err)
+			}
+		}
+
+		expected := []float64{
+			0, 1, 3, 4,
+			inf, 0, 2, 3,
+			inf, inf, 0, 1,
+			inf, inf, inf, 0,
+		}
+		compareMatrices(t, &Matrix[float64]{X: 4, P: 2, Data: expected}, paths)
+	})
+
+	t.Run("boolean reachability", func(t *testing.T) {
+		adjacency := CreateMatrix[uint8](4, 2)
+		for i, w := range weights.Data {
+			if w != inf {
+				adjacency.Data[i] = 1
+			}
+		}
+
+		reach, err := adjacency.MultiplySemiring(Boolean[uint8]{}, 0, 1, adjacency)
+		if err != nil {
+			t.Fatalf("MultiplySemiring: %v", err)
+		}
+		reach, err = reach.MultiplySemiring(Boolean[uint8]{}, 0, 1, reach)
+		if err != nil {
+			t.Fatalf("MultiplySemiring: %v", err)
+		}
+
+		expected := []uint8{
+			1, 1, 1, 1,
+			0, 1, 1, 1,
+			0, 0, 1, 1,
+			0, 0, 0, 1,
+		}
+		compareMatrices(t, &Matrix[uint8]{X: 4, P: 2, Data: expected}, reach)
+	})
+
+	t.Run("max-min bottleneck paths", func(t *testing.T) {
+		// Пропускные способности: 0 -> 1 (10), 1 -> 2 (3), 0 -> 2 (4)
+		capacity := CreateMatrix[int32](3, 2)
+		capacity.Data = []int32{
+			math.MaxInt32, 10, 4,
+			0, math.MaxInt32, 3,
+			0, 0, math.MaxInt32,
+		}
+
+		result, err := capacity.MultiplySemiring(MaxMin[int32]{}, 0, 1, capacity)
+		if err != nil {
+			t.Fatalf("MultiplySemiring: %v", err)
+		}
+		if result.Data[2] != 4 {
+			t.Errorf("Bottleneck 0 -> 2: got %d, expected 4", result.Data[2])
+		}
+		if result.Data[1] != 10 {
+			t.Errorf("Bottleneck 0 -> 1: got %d, expected 10", result.Data[1])
+		}
+	})
+
+	t.Run("max-times most reliable paths", func(t *testing.T) {
+		reliability := CreateMatrix[float64](3, 2)
+		reliability.Data = []float64{
+			1, 0.9, 0.5,
+			0, 1, 0.8,
+			0, 0, 1,
+		}
+
+		result, err := reliability.MultiplySemiring(MaxTimes[float64]{}, 0, 1, reliability)
+		if err != nil {
+			t.Fatalf("MultiplySemiring: %v", err)
+		}
+		if math.Abs(result.Data[2]-0.72) > 1e-12 {
+			t.Errorf("Reliability 0 -> 2: got %v, expected 0.72", result.Data[2])
+		}
+	})
+}
+
+// TestSemiringMultidimensional тестирует полукольца при λ > 0 против прямого вычисления
+func TestSemiringMultidimensional(t *testing.T) {
+	lhs := CreateMatrix[int64](3, 3)
+	rhs := CreateMatrix[int64](3, 3)
+	for i := range lhs.Data {
+		lhs.Data[i] = int64((i * 7) % 11)
+		rhs.Data[i] = int64((i * 5) % 13)
+	}
+
+	semirings := []Semiring[int64]{MinPlus[int64]{}, MaxMin[int64]{}, MaxTimes[int64]{}, Boolean[int64]{}}
+	for _, sr := range semirings {
+		for _, params := range [][2]uint32{{1, 1}, {2, 1}, {1, 0}, {0, 2}} {
+			lambda, mu := params[0], params[1]
+			t.Run(fmt.Sprintf("%T_lambda=%d_mu=%d", sr, lambda, mu), func(t *testing.T) {
+				expected := referenceSemiring(sr, lhs, rhs, lambda, mu)
+
+				sequential, err := lhs.MultiplySemiring(sr, lambda, mu, rhs)
+				if err != nil {
+					t.Fatalf("MultiplySemiring: %v", err)
+				}
+				compareMatrices(t, expected, sequential)
+
+				parallel, err := lhs.ParallelMultiplySemiring(sr, lambda, mu, rhs)
+				if err != nil {
+					t.Fatalf("ParallelMultiplySemiring: %v", err)
+				}
+				compareMatrices(t, expected, parallel)
+			})
+		}
+	}
+}
+
+// TestExtremeValues тестирует нули полуколец для разных типов
+func TestExtremeValues(t *testing.T) {
+	if v := maxValue[int8](); v != math.MaxInt8 {
+		t.Errorf("maxValue[int8] = %d", v)
+	}
+	if v := maxValue[uint64](); v != math.MaxUint64 {
+		t.Errorf("maxValue[uint64] = %d", v)
+	}
+	if v := minValue[int16](); v != math.MinInt16 {
+		t.Errorf("minValue[int16] = %d", v)
+	}
+	if v := minValue[float32](); !math.IsInf(float64(v), -1) {
+		t.Errorf("minValue[float32] = %v", v)
+	}
+	if v := (MinPlus[uint8]{}).Mul(200, 255); v != 255 {
+		t.Errorf("MinPlus Mul must absorb Zero, got %d", v)
+	}
+}
+
+// semiringFunc скрывает конкретный тип полукольца, чтобы проверить общий путь вычисления
+type semiringFunc[T Number] struct {
+	Semiring[T]
+}
+
+// referenceSemiring вычисляет (λ,μ)-произведение над полукольцом по определению
+func referenceSemiring[T Number](sr Semiring[T], lhs, rhs *Matrix[T], lambda, mu uint32) *Matrix[T] {
+	lhsShape, rhsShape := lhs.Shape(), rhs.Shape()
+	l := len(lhsShape) - int(lambda+mu)
+	cShape := rhsShape[lambda : lambda+mu]
+
+	resultShape := append(append([]int{}, lhsShape[:l+int(lambda)]...), rhsShape[lambda+mu:]...)
+	result, _ := NewShaped[T](resultShape...)
+
+	for idx := range result.Data {
+		resIndex := calculateIndexToArray(resultShape, idx)
+		lIndex, sIndex, mIndex := resIndex[:l], resIndex[l:l+int(lambda)], resIndex[l+int(lambda):]
+
+		sum := sr.Zero()
+		terms, _ := shapeSize(cShape)
+		for c := 0; c < terms; c++ {
+			cIndex := calculateIndexToArray(cShape, c)
+			lhsIndex := append(append(append([]uint32{}, lIndex...), sIndex...), cIndex...)
+			rhsIndex := append(append(append([]uint32{}, sIndex...), cIndex...), mIndex...)
+			sum = sr.Add(sum, sr.Mul(lhs.Data[calculateIndexFromArray(lhsIndex, lhsShape)],
+				rhs.Data[calculateIndexFromArray(rhsIndex, rhsShape)]))
+		}
+		result.Data[idx] = sum
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package mdm
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// NewShaped создаёт нулевую матрицу с собственной длиной каждой оси.
+// Если все оси равны, матрица кубическая и X, P заполняются как в NewMatrix;
+// иначе X == 0, а форма доступна через Shape
+func NewShaped[T Number](shape ...int) (*Matrix[T], error) {
+	size, err := checkShape(shape)
+	if err != nil {
+		return nil, err
+	}
+	return fromShape(shape, make([]T, size)), nil
+}
+
+// Shape возвращает длины осей матрицы. Результат — копия, её изменение
+// не влияет на матрицу
+func (m *Matrix[T]) Shape() []int {
+	return slices.Clone(m.shape())
+}
+
+// IsCubic сообщает, имеют ли все оси матрицы одинаковую длину X
+func (m *Matrix[T]) IsCubic() bool {
+	return m.dims == nil
+}
+
+// shape возвращает длины осей без копирования для некубических матриц
+func (m *Matrix[T]) shape() []int {
+	if m.dims != nil {
+		return m.dims
+	}
+	return cubicShape(m.X, m.P)
+}
+
+// hasShape сравнивает форму матрицы с shape без выделения памяти
+func (m *Matrix[T]) hasShape(shape []int) bool {
+	if m.dims != nil {
+		return slices.Equal(m.dims, shape)
+	}
+	if int(m.P) != len(shape) {
+		return false
+	}
+	for _, n := range shape {
+		if n != int(m.X) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxOrder — наибольший порядок матрицы, читаемой из внешних данных.
+// Матрица большего порядка с осями длины не меньше 2 не помещается в int,
+// поэтому ограничение отсекает лишь вырожденные формы с осями длины 0 и 1,
+// которые иначе заняли бы P элементов памяти до проверки размера
+const maxOrder = 64
+
+// checkCubic проверяет порядок и размер кубической матрицы из внешних
+// данных и возвращает число элементов
+func checkCubic(x, p uint32) (int, error) {
+	if p > maxOrder {
+		return 0, fmt.Errorf("%w: order %d exceeds %d", ErrInvalidShape, p, maxOrder)
+	}
+	size, ok := checkedPow(x, p)
+	if !ok {
+		return 0, fmt.Errorf("%w: %d^%d", ErrSizeOverflow, x, p)
+	}
+	return size, nil
+}
+
+// cubicShape возвращает форму кубической матрицы размерности X^P
+func cubicShape(x, p uint32) []int {
+	shape := make([]int, p)
+	for i := range shape {
+		shape[i] = int(x)
+	}
+	return shape
+}
+
+// fromShape создаёт матрицу формы shape над data. Кубическая форма
+// сохраняется в X и P, некубическая — в dims
+func fromShape[T Number](shape []int, data []T) *Matrix[T] {
+	m := &Matrix[T]{P: uint32(len(shape)), Data: data}
+	if len(shape) == 0 {
+		m.X = 1
+		return m
+	}
+	for _, n := range shape[1:] {
+		if n != shape[0] {
+			m.dims = slices.Clone(shape)
+			return m
+		}
+	}
+	m.X = uint32(shape[0])
+	return m
+}
+
+// checkShape проверяет длины осей и возвращает число элементов
+func checkShape(shape []int) (int, error) {
+	if len(shape) > math.MaxUint32 {
+		return 0, fmt.Errorf("%w: order %d", ErrInvalidShape, len(shape))
+	}
+	for axis, n := range shape {
+		if n < 0 || n > math.MaxUint32 {
+			return 0, fmt.Errorf("%w: axis %d has length %d", ErrInvalidShape, axis, n)
+		}
+	}
+	size, ok := shapeSize(shape)
+	if !ok {
+		return 0, fmt.Errorf("%w: shape %v", ErrSizeOverflow, shape)
+	}
+	return size, nil
+}
+
+// shapeSize вычисляет произведение длин осей; ok == false при переполнении int
+func shapeSize(shape []int) (int, bool) {
+	size := 1
+	for _, n := range shape {
+		if n == 0 {
+			return 0, true
+		}
+	}
+	for _, n := range shape {
+		if size > math.MaxInt/n {
+			return 0, false
+		}
+		size *= n
+	}
+	return size, true
+}
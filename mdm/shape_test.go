@@ -0,0 +1,172 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// TestNewShaped тестирует создание матриц с разными длинами осей
+func TestNewShaped(t *testing.T) {
+	t.Run("cubic shape is normalized", func(t *testing.T) {
+		m, err := NewShaped[uint32](3, 3, 3)
+		if err != nil {
+			t.Fatalf("NewShaped: %v", err)
+		}
+		if !m.IsCubic() || m.X != 3 || m.P != 3 || len(m.Data) != 27 {
+			t.Errorf("Expected cubic 3^3 matrix, got X=%d P=%d len=%d cubic=%v", m.X, m.P, len(m.Data), m.IsCubic())
+		}
+	})
+
+	t.Run("non-cubic shape", func(t *testing.T) {
+		m, err := NewShaped[float64](2, 3, 4)
+		if err != nil {
+			t.Fatalf("NewShaped: %v", err)
+		}
+		if m.IsCubic() || m.X != 0 || m.P != 3 || len(m.Data) != 24 {
+			t.Errorf("Expected non-cubic 2x3x4 matrix, got X=%d P=%d len=%d cubic=%v", m.X, m.P, len(m.Data), m.IsCubic())
+		}
+
+		shape := m.Shape()
+		if !slices.Equal(shape, []int{2, 3, 4}) {
+			t.Errorf("Shape: got %v, expected [2 3 4]", shape)
+		}
+		shape[0] = 100
+		if m.Shape()[0] != 2 {
+			t.Errorf("Shape must return a copy")
+		}
+	})
+
+	t.Run("cubic matrix shape", func(t *testing.T) {
+		if shape := CreateMatrix[uint8](4, 2).Shape(); !slices.Equal(shape, []int{4, 4}) {
+			t.Errorf("Shape: got %v, expected [4 4]", shape)
+		}
+	})
+
+	t.Run("invalid shapes", func(t *testing.T) {
+		if _, err := NewShaped[uint32](2, -1); !errors.Is(err, ErrInvalidShape) {
+			t.Errorf("Expected ErrInvalidShape, got %v", err)
+		}
+		if _, err := NewShaped[uint32](1<<20, 1<<20, 1<<20, 1<<20); !errors.Is(err, ErrSizeOverflow) {
+			t.Errorf("Expected ErrSizeOverflow, got %v", err)
+		}
+	})
+}
+
+// TestMixedRadixIndex тестирует индексные функции в смешанной системе счисления
+func TestMixedRadixIndex(t *testing.T) {
+	shape := []int{2, 3, 4}
+
+	for index := 0; index < 24; index++ {
+		array := calculateIndexToArray(shape, index)
+		if result := calculateIndexFromArray(array, shape); result != index {
+			t.Errorf("Index conversion inconsistent: original=%d, calculated=%d, array=%v", index, result, array)
+		}
+	}
+
+	if result := calculateIndexFromArray([]uint32{1, 2, 3}, shape); result != 23 {
+		t.Errorf("calculateIndexFromArray([1 2 3], %v) = %d, expected 23", shape, result)
+	}
+
+	vec := []uint32{0, 2, 3}
+	incrementIndexVector(vec, shape)
+	if !slices.Equal(vec, []uint32{1, 0, 0}) {
+		t.Errorf("incrementIndexVector: got %v, expected [1 0 0]", vec)
+	}
+}
+
+// TestShapedMultiplication тестирует (λ,μ)-умножение некубических матриц
+func TestShapedMultiplication(t *testing.T) {
+	shapes := []struct {
+		lhs, rhs   []int
+		lambda, mu uint32
+	}{
+		// время × датчик × канал, свёртка по каналу
+		{lhs: []int{2, 3, 4}, rhs: []int{4, 5}, lambda: 0, mu: 1},
+		// общая ось датчиков как скоттов индекс
+		{lhs: []int{2, 3, 4}, rhs: []int{3, 4, 2}, lambda: 1, mu: 1},
+		{lhs: []int{5, 2}, rhs: []int{2, 3, 2}, lambda: 1, mu: 0},
+		{lhs: []int{2, 3}, rhs: []int{4}, lambda: 0, mu: 0},
+		{lhs: []int{3, 2, 4}, rhs: []int{2, 4}, lambda: 0, mu: 2},
+		{lhs: []int{3, 2, 4}, rhs: []int{2, 4}, lambda: 2, mu: 0},
+	}
+
+	for _, tt := range shapes {
+		name := fmt.Sprintf("%v_x_%v_lambda=%d_mu=%d", tt.lhs, tt.rhs, tt.lambda, tt.mu)
+		t.Run(name, func(t *testing.T) {
+			lhs, err := NewShaped[int64](tt.lhs...)
+			if err != nil {
+				t.Fatalf("NewShaped: %v", err)
+			}
+			for i := range lhs.Data {
+				lhs.Data[i] = int64(i%7) - 2
+			}
+			rhs, err := NewShaped[int64](tt.rhs...)
+			if err != nil {
+				t.Fatalf("NewShaped: %v", err)
+			}
+			for i := range rhs.Data {
+				rhs.Data[i] = int64((i+3)%5) - 1
+			}
+
+			expected := referenceMultiplication(lhs, rhs, tt.lambda, tt.mu)
+			compareMatrices(t, expected, lhs.Multiplication(tt.lambda, tt.mu, rhs))
+			compareMatrices(t, expected, lhs.ParallelMultiplication(tt.lambda, tt.mu, rhs))
+
+			plan, err := NewShapedPlan[int64](tt.lhs, tt.rhs, tt.lambda, tt.mu)
+			if err != nil {
+				t.Fatalf("NewShapedPlan: %v", err)
+			}
+			for _, strategy := range []Strategy{StrategyDirect, StrategyGEMM, StrategyOdometer} {
+				_ = plan.SetStrategy(strategy)
+				out := plan.NewResult()
+				if err := plan.ParallelExecute(lhs, rhs, out); err != nil {
+					t.Fatalf("ParallelExecute(%v): %v", strategy, err)
+				}
+				compareMatrices(t, expected, out)
+			}
+		})
+	}
+}
+
+// TestShapedOverflowPolicies тестирует политики переполнения для некубических матриц
+func TestShapedOverflowPolicies(t *testing.T) {
+	lhs, _ := NewShaped[uint32](2, 3)
+	rhs, _ := NewShaped[uint32](3, 4)
+	for i := range lhs.Data {
+		lhs.Data[i] = uint32(i + 1)
+	}
+	for i := range rhs.Data {
+		rhs.Data[i] = uint32(i * 2)
+	}
+
+	expected := lhs.Multiplication(0, 1, rhs)
+	result, err := ParallelMultiplyOverflow(lhs, rhs, 0, 1, OverflowChecked)
+	if err != nil {
+		t.Fatalf("ParallelMultiplyOverflow: %v", err)
+	}
+	compareMatrices(t, expected, result)
+}
+
+// TestShapeMismatch тестирует проверку совпадения скоттовых и кэлиевых осей
+func TestShapeMismatch(t *testing.T) {
+	lhs, _ := NewShaped[uint32](2, 3, 4)
+	rhs, _ := NewShaped[uint32](3, 5, 4)
+
+	if _, err := lhs.MultiplyChecked(1, 1, rhs); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch for c-axis mismatch, got %v", err)
+	}
+	if _, err := lhs.MultiplyChecked(0, 1, rhs); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch for c-axis 4 vs 3, got %v", err)
+	}
+
+	// Тензорное произведение (λ=μ=0) не требует совпадения осей
+	result, err := lhs.MultiplyChecked(0, 0, rhs)
+	if err != nil {
+		t.Fatalf("MultiplyChecked(0, 0): %v", err)
+	}
+	if !slices.Equal(result.Shape(), []int{2, 3, 4, 3, 5, 4}) {
+		t.Errorf("Outer product shape: got %v", result.Shape())
+	}
+}
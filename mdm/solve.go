@@ -0,0 +1,299 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"slices"
+)
+
+// Ошибки обращения матриц и решения систем
+var (
+	ErrSingular        = errors.New("mdm: matrix is singular")
+	ErrUnsupportedType = errors.New("mdm: unsupported element type")
+)
+
+// Inverse возвращает (λ,μ)-обратную матрицу для матрицы A с осями [l, s, c],
+// где s — λ скоттовых, c — μ кэлиевых индексов, а оси l в совокупности
+// имеют столько же наборов индексов, сколько оси c.
+//
+// Обратная матрица имеет оси [s, c, l'] и для каждого s содержит
+// обращение квадратного среза A[·, s, ·]; произведение
+// A.Multiplication(lambda, mu, inv) равно 1 при l == l' и 0 иначе.
+// Исключение Гаусса с выбором ведущего элемента по модулю доступно только
+// для вещественных и комплексных типов; для целых возвращается
+// ErrUnsupportedType, для вырожденных срезов — ErrSingular. Срез порядка n
+// считается вырожденным, если ведущий элемент не превосходит n·eps·max|a|.
+// Над GF(p) используйте GF.Inverse
+func (m *Matrix[T]) Inverse(lambda, mu uint32) (*Matrix[T], error) {
+	f, err := newFloatField[T]()
+	if err != nil {
+		return nil, err
+	}
+	return inverse[T](f, m, lambda, mu)
+}
+
+// Solve решает систему A ∘(λ,μ) X = B относительно X, где A = m.
+// B должна иметь оси [l, s, m'] с теми же l и s, что у A; решение имеет
+// оси [s, c, m']. Система распадается на X^λ независимых квадратных систем.
+// Ошибки те же, что у Inverse; над GF(p) используйте GF.Solve
+func (m *Matrix[T]) Solve(lambda, mu uint32, b *Matrix[T]) (*Matrix[T], error) {
+	f, err := newFloatField[T]()
+	if err != nil {
+		return nil, err
+	}
+	return solve[T](f, m, lambda, mu, b)
+}
+
+// Inverse возвращает (λ,μ)-обратную матрицу над GF(p) точным исключением.
+// Элементы a должны быть приведены в диапазон [0, p). См. Matrix.Inverse
+func (f *GF[T]) Inverse(a *Matrix[T], lambda, mu uint32) (*Matrix[T], error) {
+	return inverse[T](f, a, lambda, mu)
+}
+
+// Solve решает систему a ∘(λ,μ) X = b над GF(p) точным исключением.
+// Элементы a и b должны быть приведены в диапазон [0, p). См. Matrix.Solve
+func (f *GF[T]) Solve(a *Matrix[T], lambda, mu uint32, b *Matrix[T]) (*Matrix[T], error) {
+	return solve[T](f, a, lambda, mu, b)
+}
+
+// pivotScore для GF(p) выбирает первый ненулевой элемент столбца
+func (f *GF[T]) pivotScore(a T) float64 {
+	if a == 0 {
+		return 0
+	}
+	return 1
+}
+
+// tolerance для GF(p) равна нулю: исключение точное
+func (f *GF[T]) tolerance(int, []T) float64 { return 0 }
+
+// field задаёт операции поля, по которым выполняется исключение Гаусса
+type field[T Number] interface {
+	Sub(a, b T) T
+	Mul(a, b T) T
+	Inv(a T) (T, error)
+
+	// pivotScore оценивает пригодность элемента как ведущего;
+	// 0 означает нулевой элемент, выбирается наибольшая оценка
+	pivotScore(a T) float64
+
+	// tolerance возвращает наибольшую оценку ведущего элемента, при которой
+	// квадратная матрица a порядка n считается вырожденной
+	tolerance(n int, a []T) float64
+}
+
+// floatField — поле вещественных или комплексных чисел с выбором
+// ведущего элемента по модулю
+type floatField[T Number] struct{}
+
+// newFloatField проверяет, что T — вещественный или комплексный тип
+func newFloatField[T Number]() (floatField[T], error) {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return floatField[T]{}, nil
+	default:
+		return floatField[T]{}, fmt.Errorf("%w: %v, want float or complex", ErrUnsupportedType, reflect.TypeFor[T]())
+	}
+}
+
+func (floatField[T]) Sub(a, b T) T { return a - b }
+func (floatField[T]) Mul(a, b T) T { return a * b }
+func (floatField[T]) Inv(a T) (T, error) {
+	if a == 0 {
+		return 0, ErrSingular
+	}
+	return 1 / a, nil
+}
+func (floatField[T]) pivotScore(a T) float64 { return magnitude(a) }
+
+// tolerance для вещественных и комплексных чисел равна n·eps·max|a|:
+// ведущий элемент такого порядка — результат ошибок округления, и
+// деление на него дало бы бесконечности вместо ErrSingular
+func (floatField[T]) tolerance(n int, a []T) float64 {
+	largest := 0.0
+	for _, v := range a {
+		largest = max(largest, magnitude(v))
+	}
+	eps := math.Nextafter(1, 2) - 1
+	if kind := reflect.TypeFor[T]().Kind(); kind == reflect.Float32 || kind == reflect.Complex64 {
+		eps = float64(math.Nextafter32(1, 2) - 1)
+	}
+	return float64(n) * eps * largest
+}
+
+// magnitude возвращает модуль вещественного или комплексного значения
+func magnitude[T Number](a T) float64 {
+	switch v := any(a).(type) {
+	case float64:
+		return math.Abs(v)
+	case float32:
+		return math.Abs(float64(v))
+	case complex128:
+		return cmplx.Abs(v)
+	case complex64:
+		return cmplx.Abs(complex128(v))
+	}
+	// Именованные типы с базовым вещественным или комплексным типом
+	rv := reflect.ValueOf(a)
+	switch rv.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		return cmplx.Abs(rv.Complex())
+	default:
+		return math.Abs(rv.Float())
+	}
+}
+
+// squareSystem описывает разбиение A [l, s, c] на квадратные срезы
+type squareSystem struct {
+	lShape, sShape, cShape []int
+	n, sSize               int
+}
+
+// newSquareSystem проверяет, что срезы A по скоттовым индексам квадратные
+func newSquareSystem[T Number](a *Matrix[T], lambda, mu uint32) (squareSystem, error) {
+	if err := a.validate(); err != nil {
+		return squareSystem{}, fmt.Errorf("lhs: %w", err)
+	}
+	shape := a.shape()
+	if uint64(lambda)+uint64(mu) > uint64(len(shape)) {
+		return squareSystem{}, fmt.Errorf("%w: lambda=%d, mu=%d, P=%d", ErrInvalidLambdaMu, lambda, mu, len(shape))
+	}
+	l := len(shape) - int(lambda+mu)
+	sys := squareSystem{
+		lShape: shape[:l],
+		sShape: shape[l : l+int(lambda)],
+		cShape: shape[l+int(lambda):],
+	}
+	lSize, _ := shapeSize(sys.lShape)
+	cSize, _ := shapeSize(sys.cShape)
+	if lSize != cSize {
+		return squareSystem{}, fmt.Errorf("%w: slices are %dx%d, want square", ErrDimensionMismatch, lSize, cSize)
+	}
+	sys.n = cSize
+	sys.sSize, _ = shapeSize(sys.sShape)
+	return sys, nil
+}
+
+// inverse обращает A, решая системы с единичной правой частью
+func inverse[T Number](f field[T], a *Matrix[T], lambda, mu uint32) (*Matrix[T], error) {
+	sys, err := newSquareSystem(a, lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	bShape := slices.Concat(sys.lShape, sys.sShape, sys.lShape)
+	size, err := checkShape(bShape)
+	if err != nil {
+		return nil, err
+	}
+	b := fromShape(bShape, make([]T, size))
+	for l := 0; l < sys.n; l++ {
+		for s := 0; s < sys.sSize; s++ {
+			b.Data[(l*sys.sSize+s)*sys.n+l] = 1
+		}
+	}
+	return solveSystem(f, sys, a, b)
+}
+
+// solve проверяет правую часть и решает систему A ∘(λ,μ) X = B
+func solve[T Number](f field[T], a *Matrix[T], lambda, mu uint32, b *Matrix[T]) (*Matrix[T], error) {
+	sys, err := newSquareSystem(a, lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.validate(); err != nil {
+		return nil, fmt.Errorf("rhs: %w", err)
+	}
+	bShape := b.shape()
+	head := len(sys.lShape) + len(sys.sShape)
+	if len(bShape) < head || !slices.Equal(bShape[:len(sys.lShape)], sys.lShape) ||
+		!slices.Equal(bShape[len(sys.lShape):head], sys.sShape) {
+		return nil, fmt.Errorf("%w: rhs shape %v does not start with %v %v",
+			ErrDimensionMismatch, bShape, sys.lShape, sys.sShape)
+	}
+	return solveSystem(f, sys, a, b)
+}
+
+// solveSystem решает квадратные системы для каждого набора скоттовых индексов
+func solveSystem[T Number](f field[T], sys squareSystem, a, b *Matrix[T]) (*Matrix[T], error) {
+	bShape := b.shape()
+	mShape := bShape[len(sys.lShape)+len(sys.sShape):]
+	xShape := slices.Concat(sys.sShape, sys.cShape, mShape)
+	size, err := checkShape(xShape)
+	if err != nil {
+		return nil, err
+	}
+	x := fromShape(xShape, make([]T, size))
+
+	n := sys.n
+	mSize, _ := shapeSize(mShape)
+	work := make([]T, n*n)
+	for s := 0; s < sys.sSize; s++ {
+		// Срез A[·, s, ·] копируется в work, B[·, s, ·] — сразу на место решения
+		rhs := x.Data[s*n*mSize : (s+1)*n*mSize]
+		for l := 0; l < n; l++ {
+			row := l*sys.sSize + s
+			copy(work[l*n:(l+1)*n], a.Data[row*n:(row+1)*n])
+			copy(rhs[l*mSize:(l+1)*mSize], b.Data[row*mSize:(row+1)*mSize])
+		}
+		if err := gaussJordan(f, n, mSize, work, rhs); err != nil {
+			return nil, fmt.Errorf("scott index %d: %w", s, err)
+		}
+	}
+	return x, nil
+}
+
+// gaussJordan приводит квадратную матрицу a (n×n) к единичной, применяя те же
+// преобразования строк к b (n×m); после завершения b содержит решение a·x = b
+func gaussJordan[T Number](f field[T], n, m int, a, b []T) error {
+	tol := f.tolerance(n, a)
+	for col := 0; col < n; col++ {
+		pivot, best := -1, 0.0
+		for r := col; r < n; r++ {
+			if score := f.pivotScore(a[r*n+col]); score > best {
+				pivot, best = r, score
+			}
+		}
+		if pivot < 0 || best <= tol {
+			return fmt.Errorf("%w: no pivot above %g in column %d", ErrSingular, tol, col)
+		}
+		if pivot != col {
+			swapRows(a, n, pivot, col)
+			swapRows(b, m, pivot, col)
+		}
+
+		inv, err := f.Inv(a[col*n+col])
+		if err != nil {
+			return err
+		}
+		for j := col; j < n; j++ {
+			a[col*n+j] = f.Mul(a[col*n+j], inv)
+		}
+		for j := 0; j < m; j++ {
+			b[col*m+j] = f.Mul(b[col*m+j], inv)
+		}
+
+		for r := 0; r < n; r++ {
+			factor := a[r*n+col]
+			if r == col || factor == 0 {
+				continue
+			}
+			for j := col; j < n; j++ {
+				a[r*n+j] = f.Sub(a[r*n+j], f.Mul(factor, a[col*n+j]))
+			}
+			for j := 0; j < m; j++ {
+				b[r*m+j] = f.Sub(b[r*m+j], f.Mul(factor, b[col*m+j]))
+			}
+		}
+	}
+	return nil
+}
+
+// swapRows меняет местами строки i и j матрицы с width столбцами
+func swapRows[T Number](data []T, width, i, j int) {
+	for k := 0; k < width; k++ {
+		data[i*width+k], data[j*width+k] = data[j*width+k], data[i*width+k]
+	}
+}
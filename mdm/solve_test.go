@@ -0,0 +1,186 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// diagonallyDominant создаёт матрицу [l, s, c] с μ осями l, у которой
+// каждый квадратный срез хорошо обусловлен
+func diagonallyDominant(X, lambda, mu uint32) *Matrix[float64] {
+	a := CreateMatrix[float64](X, lambda+2*mu)
+	n, _ := checkedPow(X, mu)
+	sSize, _ := checkedPow(X, lambda)
+	for i := range a.Data {
+		a.Data[i] = float64(i*5%7) - 3
+	}
+	for l := 0; l < n; l++ {
+		for s := 0; s < sSize; s++ {
+			a.Data[(l*sSize+s)*n+l] += float64(4 * n)
+		}
+	}
+	return a
+}
+
+// TestInverseAndSolve тестирует обращение и решение систем для всех допустимых λ и μ
+func TestInverseAndSolve(t *testing.T) {
+	const eps = 1e-9
+	for X := uint32(2); X <= 3; X++ {
+		for lambda := uint32(0); lambda <= 2; lambda++ {
+			for mu := uint32(0); mu <= 2; mu++ {
+				name := fmt.Sprintf("X=%d_lambda=%d_mu=%d", X, lambda, mu)
+				t.Run(name, func(t *testing.T) {
+					a := diagonallyDominant(X, lambda, mu)
+
+					inv, err := a.Inverse(lambda, mu)
+					if err != nil {
+						t.Fatalf("Inverse: %v", err)
+					}
+					product := a.Multiplication(lambda, mu, inv)
+					n, _ := checkedPow(X, mu)
+					sSize, _ := checkedPow(X, lambda)
+					for idx, v := range product.Data {
+						l, rest := idx/(sSize*n), idx%n
+						expected := 0.0
+						if l == rest {
+							expected = 1
+						}
+						if math.Abs(v-expected) > eps {
+							t.Fatalf("A·inv at %d: got %g, expected %g", idx, v, expected)
+						}
+					}
+
+					b, err := NewShaped[float64](append(a.Shape()[:mu+lambda], 2)...)
+					if err != nil {
+						t.Fatalf("NewShaped: %v", err)
+					}
+					for i := range b.Data {
+						b.Data[i] = float64(i%4) + 0.5
+					}
+					x, err := a.Solve(lambda, mu, b)
+					if err != nil {
+						t.Fatalf("Solve: %v", err)
+					}
+					check := a.Multiplication(lambda, mu, x)
+					for i := range b.Data {
+						if math.Abs(check.Data[i]-b.Data[i]) > eps {
+							t.Fatalf("A·x at %d: got %g, expected %g", i, check.Data[i], b.Data[i])
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestSolveComplex тестирует решение системы с комплексными элементами
+func TestSolveComplex(t *testing.T) {
+	a := CreateMatrix[complex128](2, 2)
+	a.Data = []complex128{0, 1i, 2, 1}
+	b := CreateMatrix[complex128](2, 1)
+	b.Data = []complex128{1i, 3}
+
+	x, err := a.Solve(0, 1, b)
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+	// i·x1 = i, 2·x0 + x1 = 3
+	expected := []complex128{1, 1}
+	for i := range expected {
+		if cmplx.Abs(x.Data[i]-expected[i]) > 1e-12 {
+			t.Errorf("x[%d]: got %v, expected %v", i, x.Data[i], expected[i])
+		}
+	}
+}
+
+// TestSolveErrors тестирует ошибки обращения и решения систем
+func TestSolveErrors(t *testing.T) {
+	singular := CreateMatrix[float64](2, 2)
+	singular.Data = []float64{1, 2, 2, 4}
+	if _, err := singular.Inverse(0, 1); !errors.Is(err, ErrSingular) {
+		t.Errorf("Singular matrix: expected ErrSingular, got %v", err)
+	}
+
+	// Вырожденная матрица, ведущий элемент которой после исключения
+	// отличен от нуля лишь из-за округления
+	rankDeficient := CreateMatrix[float64](3, 2)
+	rankDeficient.Data = []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+	if _, err := rankDeficient.Inverse(0, 1); !errors.Is(err, ErrSingular) {
+		t.Errorf("Numerically singular matrix: expected ErrSingular, got %v", err)
+	}
+	rankDeficient32 := CreateMatrix[complex64](3, 2)
+	for i, v := range rankDeficient.Data {
+		rankDeficient32.Data[i] = complex(float32(v), float32(v)/3)
+	}
+	if _, err := rankDeficient32.Solve(0, 1, CreateMatrix[complex64](3, 1)); !errors.Is(err, ErrSingular) {
+		t.Errorf("Numerically singular complex matrix: expected ErrSingular, got %v", err)
+	}
+
+	if _, err := CreateMatrix[int32](2, 2).Inverse(0, 1); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Integer matrix: expected ErrUnsupportedType, got %v", err)
+	}
+
+	if _, err := CreateMatrix[float64](2, 3).Inverse(0, 1); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Non-square slices: expected ErrDimensionMismatch, got %v", err)
+	}
+
+	if _, err := CreateMatrix[float64](2, 2).Inverse(2, 1); !errors.Is(err, ErrInvalidLambdaMu) {
+		t.Errorf("lambda+mu > P: expected ErrInvalidLambdaMu, got %v", err)
+	}
+
+	a := diagonallyDominant(2, 1, 1)
+	if _, err := a.Solve(1, 1, CreateMatrix[float64](3, 3)); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Mismatched rhs: expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := a.Solve(1, 1, nil); !errors.Is(err, ErrNilMatrix) {
+		t.Errorf("Nil rhs: expected ErrNilMatrix, got %v", err)
+	}
+}
+
+// TestModularInverse тестирует точное обращение над GF(p)
+func TestModularInverse(t *testing.T) {
+	f, err := NewGF[uint32](7)
+	if err != nil {
+		t.Fatalf("NewGF: %v", err)
+	}
+
+	a := CreateMatrix[uint32](3, 3)
+	for i := range a.Data {
+		a.Data[i] = uint32(i*i+3*i+1) % 7
+	}
+	inv, err := f.Inverse(a, 1, 1)
+	if err != nil {
+		t.Fatalf("Inverse: %v", err)
+	}
+	product, err := a.MultiplySemiring(f, 1, 1, inv)
+	if err != nil {
+		t.Fatalf("MultiplySemiring: %v", err)
+	}
+	for idx, v := range product.Data {
+		expected := uint32(0)
+		if idx/9 == idx%3 {
+			expected = 1
+		}
+		if v != expected {
+			t.Fatalf("A·inv at %d: got %d, expected %d", idx, v, expected)
+		}
+	}
+
+	b := CreateMatrix[uint32](3, 2)
+	b.Data = []uint32{1, 2, 3, 4, 5, 6, 0, 1, 2}
+	x, err := f.Solve(a, 1, 1, b)
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+	check, _ := a.MultiplySemiring(f, 1, 1, x)
+	compareMatrices(t, b, check)
+
+	singular := CreateMatrix[uint32](2, 2)
+	singular.Data = []uint32{1, 3, 2, 6}
+	if _, err := f.Inverse(singular, 0, 1); !errors.Is(err, ErrSingular) {
+		t.Errorf("Singular matrix: expected ErrSingular, got %v", err)
+	}
+}
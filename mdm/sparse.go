@@ -0,0 +1,266 @@
+package mdm
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// Sparse — разреженная многомерная матрица в формате COO: хранятся только
+// ненулевые элементы и их многомерные индексы, упорядоченные как в Data
+// плотной матрицы той же формы
+type Sparse[T Number] struct {
+	shape []int
+
+	// indices[k*P : (k+1)*P] — многомерный индекс k-го ненулевого элемента
+	indices []uint32
+	values  []T
+}
+
+// NewSparse создаёт разреженную матрицу формы shape из многомерных индексов
+// и значений. Повторяющиеся индексы суммируются, нули отбрасываются.
+// Ошибки сопоставляются с ErrInvalidShape, ErrSizeOverflow, ErrDataLength
+// и ErrIndexOutOfRange
+func NewSparse[T Number](shape []int, indices [][]int, values []T) (*Sparse[T], error) {
+	if _, err := checkShape(shape); err != nil {
+		return nil, err
+	}
+	if len(indices) != len(values) {
+		return nil, fmt.Errorf("%w: %d indices for %d values", ErrDataLength, len(indices), len(values))
+	}
+
+	type entry struct {
+		offset int
+		value  T
+	}
+	entries := make([]entry, len(values))
+	for k, idx := range indices {
+		if len(idx) != len(shape) {
+			return nil, fmt.Errorf("%w: got %d indices for order %d", ErrIndexOutOfRange, len(idx), len(shape))
+		}
+		var offset int
+		for axis, i := range idx {
+			if i < 0 || i >= shape[axis] {
+				return nil, fmt.Errorf("%w: index %d on axis %d of length %d", ErrIndexOutOfRange, i, axis, shape[axis])
+			}
+			offset = offset*shape[axis] + i
+		}
+		entries[k] = entry{offset, values[k]}
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int { return cmp.Compare(a.offset, b.offset) })
+
+	s := &Sparse[T]{shape: slices.Clone(shape)}
+	for k := 0; k < len(entries); {
+		offset, sum := entries[k].offset, entries[k].value
+		for k++; k < len(entries) && entries[k].offset == offset; k++ {
+			sum += entries[k].value
+		}
+		s.append(offset, sum)
+	}
+	return s, nil
+}
+
+// ToSparse возвращает разреженное представление матрицы без нулевых элементов
+func (m *Matrix[T]) ToSparse() *Sparse[T] {
+	s := &Sparse[T]{shape: slices.Clone(m.shape())}
+	for offset, v := range m.Data {
+		s.append(offset, v)
+	}
+	return s
+}
+
+// Dense возвращает плотную матрицу с теми же элементами
+func (s *Sparse[T]) Dense() *Matrix[T] {
+	size, _ := shapeSize(s.shape)
+	m := fromShape(s.shape, make([]T, size))
+	for k, v := range s.values {
+		m.Data[calculateIndexFromArray(s.index(k), s.shape)] = v
+	}
+	return m
+}
+
+// Shape возвращает длины осей матрицы
+func (s *Sparse[T]) Shape() []int {
+	return slices.Clone(s.shape)
+}
+
+// NNZ возвращает число хранимых ненулевых элементов
+func (s *Sparse[T]) NNZ() int {
+	return len(s.values)
+}
+
+// At возвращает элемент с многомерным индексом idx; отсутствующие элементы равны 0.
+// Паникует с ErrIndexOutOfRange при неверном индексе
+func (s *Sparse[T]) At(idx ...int) T {
+	if len(idx) != len(s.shape) {
+		panic(fmt.Errorf("%w: got %d indices for order %d", ErrIndexOutOfRange, len(idx), len(s.shape)))
+	}
+	var offset int
+	for axis, i := range idx {
+		if i < 0 || i >= s.shape[axis] {
+			panic(fmt.Errorf("%w: index %d on axis %d of length %d", ErrIndexOutOfRange, i, axis, s.shape[axis]))
+		}
+		offset = offset*s.shape[axis] + i
+	}
+	k, found := s.search(offset)
+	if !found {
+		return 0
+	}
+	return s.values[k]
+}
+
+// All перебирает ненулевые элементы в порядке хранения вместе с их индексами.
+// Срез индекса переиспользуется между итерациями
+func (s *Sparse[T]) All() iter.Seq2[[]int, T] {
+	return func(yield func([]int, T) bool) {
+		index := make([]int, len(s.shape))
+		for k, v := range s.values {
+			for axis, i := range s.index(k) {
+				index[axis] = int(i)
+			}
+			if !yield(index, v) {
+				return
+			}
+		}
+	}
+}
+
+// MultiplyDense выполняет (λ,μ)-умножение разреженной матрицы на плотную.
+// Перебираются только ненулевые элементы левого сомножителя, результат плотный.
+// Ошибки те же, что у MultiplyChecked
+func (s *Sparse[T]) MultiplyDense(lambda, mu uint32, other *Matrix[T]) (*Matrix[T], error) {
+	if s == nil {
+		return nil, fmt.Errorf("lhs: %w", ErrNilMatrix)
+	}
+	if err := other.validate(); err != nil {
+		return nil, fmt.Errorf("rhs: %w", err)
+	}
+	shape, size, err := resultShape(s.shape, other.shape(), lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	result := fromShape(shape, make([]T, size))
+	sSize, cSize, mSize := s.groupSizes(other.shape(), lambda, mu)
+
+	for k, a := range s.values {
+		offset := calculateIndexFromArray(s.index(k), s.shape)
+		row, ci := offset/cSize, offset%cSize
+		rhs := other.Data[(row%sSize*cSize+ci)*mSize:][:mSize]
+		out := result.Data[row*mSize:][:mSize]
+		for mi, b := range rhs {
+			out[mi] += a * b
+		}
+	}
+	return result, nil
+}
+
+// MultiplySparse выполняет (λ,μ)-умножение двух разреженных матриц.
+// Для каждого ненулевого a[l, s, c] перебираются только ненулевые b[s, c, ·];
+// строки результата накапливаются по очереди, нули в результат не попадают
+func (s *Sparse[T]) MultiplySparse(lambda, mu uint32, other *Sparse[T]) (*Sparse[T], error) {
+	if s == nil {
+		return nil, fmt.Errorf("lhs: %w", ErrNilMatrix)
+	}
+	if other == nil {
+		return nil, fmt.Errorf("rhs: %w", ErrNilMatrix)
+	}
+	shape, _, err := resultShape(s.shape, other.shape, lambda, mu)
+	if err != nil {
+		return nil, err
+	}
+	result := &Sparse[T]{shape: shape}
+	sSize, cSize, mSize := s.groupSizes(other.shape, lambda, mu)
+
+	// Ненулевые элементы правого сомножителя с одинаковыми [s, c] идут подряд;
+	// runs сопоставляет набору [s, c] диапазон в other.values
+	rhsOffsets := make([]int, len(other.values))
+	runs := make(map[int][2]int)
+	for k := range other.values {
+		rhsOffsets[k] = calculateIndexFromArray(other.index(k), other.shape)
+		key := rhsOffsets[k] / mSize
+		run, ok := runs[key]
+		if !ok {
+			run[0] = k
+		}
+		run[1] = k + 1
+		runs[key] = run
+	}
+
+	acc := make(map[int]T)
+	var cols []int
+	row := -1
+	flush := func() {
+		slices.Sort(cols)
+		for _, mi := range cols {
+			result.append(row*mSize+mi, acc[mi])
+		}
+		clear(acc)
+		cols = cols[:0]
+	}
+	for k, a := range s.values {
+		offset := calculateIndexFromArray(s.index(k), s.shape)
+		if offset/cSize != row {
+			flush()
+			row = offset / cSize
+		}
+		run, ok := runs[row%sSize*cSize+offset%cSize]
+		if !ok {
+			continue
+		}
+		for r := run[0]; r < run[1]; r++ {
+			mi := rhsOffsets[r] % mSize
+			if _, seen := acc[mi]; !seen {
+				cols = append(cols, mi)
+			}
+			acc[mi] += a * other.values[r]
+		}
+	}
+	flush()
+	return result, nil
+}
+
+// groupSizes возвращает число наборов скоттовых, кэлиевых и правых
+// свободных индексов для проверенных форм
+func (s *Sparse[T]) groupSizes(rhsShape []int, lambda, mu uint32) (int, int, int) {
+	l := len(s.shape) - int(lambda+mu)
+	sSize, _ := shapeSize(s.shape[l : l+int(lambda)])
+	cSize, _ := shapeSize(s.shape[l+int(lambda):])
+	mSize, _ := shapeSize(rhsShape[lambda+mu:])
+	return sSize, cSize, mSize
+}
+
+// index возвращает многомерный индекс k-го ненулевого элемента
+func (s *Sparse[T]) index(k int) []uint32 {
+	p := len(s.shape)
+	return s.indices[k*p : (k+1)*p]
+}
+
+// search ищет элемент со смещением offset двоичным поиском
+func (s *Sparse[T]) search(offset int) (int, bool) {
+	lo, hi := 0, len(s.values)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		switch c := calculateIndexFromArray(s.index(mid), s.shape); {
+		case c == offset:
+			return mid, true
+		case c < offset:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return lo, false
+}
+
+// append добавляет в конец ненулевой элемент со смещением offset;
+// смещения должны возрастать
+func (s *Sparse[T]) append(offset int, v T) {
+	if v == 0 {
+		return
+	}
+	start := len(s.indices)
+	s.indices = append(s.indices, make([]uint32, len(s.shape))...)
+	fastCalculateIndexToArray(s.shape, offset, s.indices[start:])
+	s.values = append(s.values, v)
+}
@@ -0,0 +1,113 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// sparseFilled создаёт плотную матрицу, у которой ненулевой примерно каждый пятый элемент
+func sparseFilled(X, P uint32, seed int) *Matrix[int64] {
+	m := CreateMatrix[int64](X, P)
+	for i := range m.Data {
+		if (i*seed+1)%5 == 0 {
+			m.Data[i] = int64(i%7) - 3
+		}
+	}
+	return m
+}
+
+// TestSparseMultiplication тестирует совпадение разреженных умножений с плотным для всех λ и μ
+func TestSparseMultiplication(t *testing.T) {
+	X := uint32(3)
+	for lhsP := uint32(1); lhsP <= 3; lhsP++ {
+		for rhsP := uint32(1); rhsP <= 3; rhsP++ {
+			lhs, rhs := sparseFilled(X, lhsP, 3), sparseFilled(X, rhsP, 7)
+			for lambda := uint32(0); lambda <= min(lhsP, rhsP); lambda++ {
+				for mu := uint32(0); lambda+mu <= min(lhsP, rhsP); mu++ {
+					name := fmt.Sprintf("lhsP=%d_rhsP=%d_lambda=%d_mu=%d", lhsP, rhsP, lambda, mu)
+					t.Run(name, func(t *testing.T) {
+						expected := lhs.Multiplication(lambda, mu, rhs)
+
+						dense, err := lhs.ToSparse().MultiplyDense(lambda, mu, rhs)
+						if err != nil {
+							t.Fatalf("MultiplyDense: %v", err)
+						}
+						compareMatrices(t, expected, dense)
+
+						sparse, err := lhs.ToSparse().MultiplySparse(lambda, mu, rhs.ToSparse())
+						if err != nil {
+							t.Fatalf("MultiplySparse: %v", err)
+						}
+						compareMatrices(t, expected, sparse.Dense())
+						if sparse.NNZ() != expected.ToSparse().NNZ() {
+							t.Errorf("NNZ: got %d, expected %d", sparse.NNZ(), expected.ToSparse().NNZ())
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+// TestSparseConversion тестирует построение разреженной матрицы и доступ к элементам
+func TestSparseConversion(t *testing.T) {
+	s, err := NewSparse([]int{2, 3, 4}, [][]int{{1, 2, 3}, {0, 0, 1}, {1, 2, 3}, {0, 1, 0}, {0, 1, 0}}, []float64{1, 2, 3, 5, -5})
+	if err != nil {
+		t.Fatalf("NewSparse: %v", err)
+	}
+	// Повторяющиеся индексы суммируются, нулевая сумма отбрасывается
+	if s.NNZ() != 2 || s.At(1, 2, 3) != 4 || s.At(0, 0, 1) != 2 || s.At(0, 1, 0) != 0 {
+		t.Errorf("NewSparse: nnz=%d, values %v %v %v", s.NNZ(), s.At(1, 2, 3), s.At(0, 0, 1), s.At(0, 1, 0))
+	}
+
+	var indices [][]int
+	for index := range s.All() {
+		indices = append(indices, slices.Clone(index))
+	}
+	if expected := [][]int{{0, 0, 1}, {1, 2, 3}}; !slices.EqualFunc(indices, expected, slices.Equal) {
+		t.Errorf("All: got %v, expected %v", indices, expected)
+	}
+
+	dense := s.Dense()
+	if !slices.Equal(dense.Shape(), []int{2, 3, 4}) || dense.At(1, 2, 3) != 4 || dense.At(0, 0, 1) != 2 {
+		t.Errorf("Dense: shape %v, data %v", dense.Shape(), dense.Data)
+	}
+	compareMatrices(t, dense, dense.ToSparse().Dense())
+}
+
+// TestSparseErrors тестирует проверку разреженных матриц и операндов
+func TestSparseErrors(t *testing.T) {
+	if _, err := NewSparse([]int{2, 2}, [][]int{{0, 2}}, []int32{1}); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Index out of range: expected ErrIndexOutOfRange, got %v", err)
+	}
+	if _, err := NewSparse([]int{2, 2}, [][]int{{0, 1}}, []int32{1, 2}); !errors.Is(err, ErrDataLength) {
+		t.Errorf("Length mismatch: expected ErrDataLength, got %v", err)
+	}
+	if _, err := NewSparse([]int{2, -1}, nil, []int32{}); !errors.Is(err, ErrInvalidShape) {
+		t.Errorf("Invalid shape: expected ErrInvalidShape, got %v", err)
+	}
+
+	s := sparseFilled(3, 2, 3).ToSparse()
+	if _, err := s.MultiplyDense(0, 1, CreateMatrix[int64](2, 2)); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("MultiplyDense mismatch: expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := s.MultiplySparse(2, 1, s); !errors.Is(err, ErrInvalidLambdaMu) {
+		t.Errorf("MultiplySparse invalid lambda/mu: expected ErrInvalidLambdaMu, got %v", err)
+	}
+	if _, err := s.MultiplySparse(0, 1, nil); !errors.Is(err, ErrNilMatrix) {
+		t.Errorf("MultiplySparse nil: expected ErrNilMatrix, got %v", err)
+	}
+	if _, err := s.MultiplyDense(0, 1, nil); !errors.Is(err, ErrNilMatrix) {
+		t.Errorf("MultiplyDense nil: expected ErrNilMatrix, got %v", err)
+	}
+	var empty *Sparse[int64]
+	if _, err := empty.MultiplySparse(0, 1, s); !errors.Is(err, ErrNilMatrix) {
+		t.Errorf("MultiplySparse nil receiver: expected ErrNilMatrix, got %v", err)
+	}
+	if _, err := empty.MultiplyDense(0, 1, CreateMatrix[int64](3, 2)); !errors.Is(err, ErrNilMatrix) {
+		t.Errorf("MultiplyDense nil receiver: expected ErrNilMatrix, got %v", err)
+	}
+	expectPanic(t, ErrIndexOutOfRange, func() { s.At(3, 0) })
+}
@@ -0,0 +1,188 @@
+package mdm
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// View — представление матрицы с произвольными страйдами осей без копирования
+// данных. Изменения через Set видны в исходной матрице и наоборот
+type View[T Number] struct {
+	data    []T
+	shape   []int
+	strides []int
+	offset  int
+}
+
+// View возвращает представление матрицы в исходном порядке осей
+func (m *Matrix[T]) View() *View[T] {
+	shape := slices.Clone(m.shape())
+	return &View[T]{data: m.Data, shape: shape, strides: shapeStrides(shape)}
+}
+
+// Transpose возвращает новую матрицу с переставленными осями:
+// ось i результата — это ось perm[i] исходной матрицы, то есть
+// r[i_perm[0], ..., i_perm[P-1]] = m[i_0, ..., i_{P-1}].
+// perm должен быть перестановкой 0..P-1, иначе возвращается ErrInvalidAxis
+func (m *Matrix[T]) Transpose(perm []int) (*Matrix[T], error) {
+	v, err := m.TransposeView(perm)
+	if err != nil {
+		return nil, err
+	}
+	return v.Materialize(), nil
+}
+
+// TransposeView — вариант Transpose без копирования данных
+func (m *Matrix[T]) TransposeView(perm []int) (*View[T], error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return m.View().Transpose(perm)
+}
+
+// TransposeK выполняет (k)-транспонирование по Соколову: первые k индексов
+// циклически переносятся в конец, r[i_{k+1}, ..., i_P, i_1, ..., i_k] = m[i_1, ..., i_P].
+// k берётся по модулю P, отрицательные k сдвигают индексы в обратную сторону
+func (m *Matrix[T]) TransposeK(k int) (*Matrix[T], error) {
+	v, err := m.TransposeKView(k)
+	if err != nil {
+		return nil, err
+	}
+	return v.Materialize(), nil
+}
+
+// TransposeKView — вариант TransposeK без копирования данных
+func (m *Matrix[T]) TransposeKView(k int) (*View[T], error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return m.View().TransposeK(k)
+}
+
+// Shape возвращает длины осей представления
+func (v *View[T]) Shape() []int {
+	return slices.Clone(v.shape)
+}
+
+// Transpose переставляет оси представления без копирования данных;
+// perm имеет тот же смысл, что в Matrix.Transpose
+func (v *View[T]) Transpose(perm []int) (*View[T], error) {
+	if err := checkPerm(perm, len(v.shape)); err != nil {
+		return nil, err
+	}
+	t := &View[T]{
+		data:    v.data,
+		shape:   make([]int, len(perm)),
+		strides: make([]int, len(perm)),
+		offset:  v.offset,
+	}
+	for i, axis := range perm {
+		t.shape[i] = v.shape[axis]
+		t.strides[i] = v.strides[axis]
+	}
+	return t, nil
+}
+
+// TransposeK выполняет (k)-транспонирование представления без копирования данных
+func (v *View[T]) TransposeK(k int) (*View[T], error) {
+	return v.Transpose(cyclicPerm(len(v.shape), k))
+}
+
+// At возвращает элемент представления с многомерным индексом idx.
+// Паникует с ErrIndexOutOfRange при неверном индексе
+func (v *View[T]) At(idx ...int) T {
+	return v.data[v.index(idx)]
+}
+
+// Set записывает value в элемент представления с многомерным индексом idx.
+// Паникует с ErrIndexOutOfRange при неверном индексе
+func (v *View[T]) Set(value T, idx ...int) {
+	v.data[v.index(idx)] = value
+}
+
+// All перебирает элементы представления в порядке его осей.
+// Срез индекса переиспользуется между итерациями
+func (v *View[T]) All() iter.Seq2[[]int, T] {
+	return func(yield func([]int, T) bool) {
+		size, _ := shapeSize(v.shape)
+		index := make([]int, len(v.shape))
+		offset := v.offset
+		for range size {
+			if !yield(index, v.data[offset]) {
+				return
+			}
+			offset = v.step(index, offset)
+		}
+	}
+}
+
+// Materialize копирует представление в новую матрицу с обычным порядком хранения
+func (v *View[T]) Materialize() *Matrix[T] {
+	size, _ := shapeSize(v.shape)
+	data := make([]T, size)
+	index := make([]int, len(v.shape))
+	offset := v.offset
+	for i := range data {
+		data[i] = v.data[offset]
+		offset = v.step(index, offset)
+	}
+	return fromShape(v.shape, data)
+}
+
+// step увеличивает многомерный индекс как одометр и возвращает смещение
+// следующего элемента, пересчитанное только по изменившимся осям
+func (v *View[T]) step(index []int, offset int) int {
+	for axis := len(index) - 1; axis >= 0; axis-- {
+		if index[axis] < v.shape[axis]-1 {
+			index[axis]++
+			return offset + v.strides[axis]
+		}
+		offset -= index[axis] * v.strides[axis]
+		index[axis] = 0
+	}
+	return offset
+}
+
+// index переводит многомерный индекс в смещение в data с проверкой границ
+func (v *View[T]) index(idx []int) int {
+	if len(idx) != len(v.shape) {
+		panic(fmt.Errorf("%w: got %d indices for order %d", ErrIndexOutOfRange, len(idx), len(v.shape)))
+	}
+	offset := v.offset
+	for axis, i := range idx {
+		if i < 0 || i >= v.shape[axis] {
+			panic(fmt.Errorf("%w: index %d on axis %d of length %d", ErrIndexOutOfRange, i, axis, v.shape[axis]))
+		}
+		offset += i * v.strides[axis]
+	}
+	return offset
+}
+
+// checkPerm проверяет, что perm — перестановка 0..p-1
+func checkPerm(perm []int, p int) error {
+	if len(perm) != p {
+		return fmt.Errorf("%w: permutation %v for order %d", ErrInvalidAxis, perm, p)
+	}
+	seen := make([]bool, p)
+	for _, axis := range perm {
+		if axis < 0 || axis >= p || seen[axis] {
+			return fmt.Errorf("%w: permutation %v for order %d", ErrInvalidAxis, perm, p)
+		}
+		seen[axis] = true
+	}
+	return nil
+}
+
+// cyclicPerm возвращает перестановку (k)-транспонирования для порядка p
+func cyclicPerm(p, k int) []int {
+	perm := make([]int, p)
+	if p == 0 {
+		return perm
+	}
+	k = (k%p + p) % p
+	for i := range perm {
+		perm[i] = (i + k) % p
+	}
+	return perm
+}
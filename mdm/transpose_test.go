@@ -0,0 +1,130 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// TestTranspose тестирует перестановку осей для всех перестановок порядка 3
+func TestTranspose(t *testing.T) {
+	m := newSequence(t, 2, 3, 4)
+	perms := [][]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+
+	for _, perm := range perms {
+		t.Run(fmt.Sprint(perm), func(t *testing.T) {
+			r, err := m.Transpose(perm)
+			if err != nil {
+				t.Fatalf("Transpose: %v", err)
+			}
+			v, err := m.TransposeView(perm)
+			if err != nil {
+				t.Fatalf("TransposeView: %v", err)
+			}
+
+			expectedShape := []int{m.Shape()[perm[0]], m.Shape()[perm[1]], m.Shape()[perm[2]]}
+			if !slices.Equal(r.Shape(), expectedShape) || !slices.Equal(v.Shape(), expectedShape) {
+				t.Fatalf("Shape: got %v and %v, expected %v", r.Shape(), v.Shape(), expectedShape)
+			}
+
+			permuted := make([]int, 3)
+			for index, value := range m.All() {
+				for i, axis := range perm {
+					permuted[i] = index[axis]
+				}
+				if r.At(permuted...) != value || v.At(permuted...) != value {
+					t.Fatalf("Element %v: got %d and %d, expected %d", index, r.At(permuted...), v.At(permuted...), value)
+				}
+			}
+
+			count := 0
+			for index, value := range v.All() {
+				if r.At(index...) != value {
+					t.Fatalf("View.All at %v: got %d, expected %d", index, value, r.At(index...))
+				}
+				count++
+			}
+			if count != len(m.Data) {
+				t.Errorf("View.All: visited %d elements, expected %d", count, len(m.Data))
+			}
+		})
+	}
+}
+
+// TestTransposeK тестирует (k)-транспонирование по Соколову
+func TestTransposeK(t *testing.T) {
+	m := newSequence(t, 2, 3, 4, 5)
+
+	r, err := m.TransposeK(1)
+	if err != nil {
+		t.Fatalf("TransposeK: %v", err)
+	}
+	if !slices.Equal(r.Shape(), []int{3, 4, 5, 2}) {
+		t.Errorf("Shape: got %v, expected [3 4 5 2]", r.Shape())
+	}
+	if r.At(2, 1, 4, 1) != m.At(1, 2, 1, 4) {
+		t.Errorf("Element: got %d, expected %d", r.At(2, 1, 4, 1), m.At(1, 2, 1, 4))
+	}
+
+	// (k)-транспонирование, применённое P раз по одному индексу, возвращает исходную матрицу
+	v := m.View()
+	for range m.P {
+		if v, err = v.TransposeK(1); err != nil {
+			t.Fatalf("View.TransposeK: %v", err)
+		}
+	}
+	compareMatrices(t, m, v.Materialize())
+
+	for _, k := range []int{0, 4, -4} {
+		same, err := m.TransposeK(k)
+		if err != nil {
+			t.Fatalf("TransposeK(%d): %v", k, err)
+		}
+		compareMatrices(t, m, same)
+	}
+	back, err := r.TransposeK(-1)
+	if err != nil {
+		t.Fatalf("TransposeK(-1): %v", err)
+	}
+	compareMatrices(t, m, back)
+}
+
+// TestTransposeView тестирует, что представление разделяет данные с матрицей
+func TestTransposeView(t *testing.T) {
+	m := CreateMatrix[uint32](3, 2)
+	copy(m.Data, []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	v, err := m.TransposeView([]int{1, 0})
+	if err != nil {
+		t.Fatalf("TransposeView: %v", err)
+	}
+	v.Set(42, 0, 2)
+	if m.At(2, 0) != 42 {
+		t.Errorf("View.Set must write through: m[2, 0] = %d", m.At(2, 0))
+	}
+	m.Set(7, 1, 2)
+	if v.At(2, 1) != 7 {
+		t.Errorf("View must see matrix updates: v[2, 1] = %d", v.At(2, 1))
+	}
+
+	// Транспонированный левый сомножитель в обычном матричном умножении
+	lhs := v.Materialize()
+	expected := referenceMultiplication(lhs, m, 0, 1)
+	compareMatrices(t, expected, lhs.Multiplication(0, 1, m))
+}
+
+// TestTransposeErrors тестирует проверку перестановок
+func TestTransposeErrors(t *testing.T) {
+	m := CreateMatrix[uint32](2, 3)
+	for _, perm := range [][]int{{0, 1}, {0, 1, 1}, {0, 1, 3}, {-1, 0, 1}} {
+		if _, err := m.Transpose(perm); !errors.Is(err, ErrInvalidAxis) {
+			t.Errorf("Transpose(%v): expected ErrInvalidAxis, got %v", perm, err)
+		}
+	}
+	var nilMatrix *Matrix[uint32]
+	if _, err := nilMatrix.TransposeK(1); !errors.Is(err, ErrNilMatrix) {
+		t.Errorf("Nil matrix: expected ErrNilMatrix, got %v", err)
+	}
+	expectPanic(t, ErrIndexOutOfRange, func() { m.View().At(0, 0, 2) })
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// metrics — счётчики сервера, выдаваемые /metrics
+type metrics struct {
+	inFlight atomic.Int64
+	// elements — число вычисленных элементов результатов, включая
+	// выполняющиеся и прерванные запросы
+	elements atomic.Uint64
+
+	mu       sync.Mutex
+	codes    map[int]uint64
+	seconds  float64
+	observed uint64
+}
+
+func (m *metrics) count(code int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.codes[code]++
+}
+
+// observe учитывает длительность успешного умножения
+func (m *metrics) observe(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seconds += d.Seconds()
+	m.observed++
+}
+
+// handleMetrics выдаёт метрики в текстовом формате Prometheus
+func (s *Server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	m := &s.metrics
+	m.mu.Lock()
+	codes := make([]int, 0, len(m.codes))
+	for code := range m.codes {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	counts := make([]uint64, len(codes))
+	for i, code := range codes {
+		counts[i] = m.codes[code]
+	}
+	seconds, observed := m.seconds, m.observed
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintln(w, "# HELP mmmatrix_requests_total Multiplication requests by HTTP status code.")
+	fmt.Fprintln(w, "# TYPE mmmatrix_requests_total counter")
+	for i, code := range codes {
+		fmt.Fprintf(w, "mmmatrix_requests_total{code=\"%d\"} %d\n", code, counts[i])
+	}
+	fmt.Fprintln(w, "# HELP mmmatrix_requests_in_flight Multiplication requests being processed.")
+	fmt.Fprintln(w, "# TYPE mmmatrix_requests_in_flight gauge")
+	fmt.Fprintf(w, "mmmatrix_requests_in_flight %d\n", m.inFlight.Load())
+	fmt.Fprintln(w, "# HELP mmmatrix_result_elements_total Result elements computed.")
+	fmt.Fprintln(w, "# TYPE mmmatrix_result_elements_total counter")
+	fmt.Fprintf(w, "mmmatrix_result_elements_total %d\n", m.elements.Load())
+	fmt.Fprintln(w, "# HELP mmmatrix_multiply_seconds Duration of successful multiplication requests.")
+	fmt.Fprintln(w, "# TYPE mmmatrix_multiply_seconds summary")
+	fmt.Fprintf(w, "mmmatrix_multiply_seconds_sum %g\n", seconds)
+	fmt.Fprintf(w, "mmmatrix_multiply_seconds_count %d\n", observed)
+}
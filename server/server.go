@@ -0,0 +1,225 @@
+// Package server предоставляет (λ,μ)-умножение многомерных матриц как
+// HTTP/JSON-сервис.
+//
+// Конечные точки:
+//
+//	POST /v1/multiply  умножение двух матриц, см. MultiplyRequest
+//	GET  /healthz      проверка работоспособности
+//	GET  /metrics      метрики в текстовом формате Prometheus
+//
+// Матрицы передаются в JSON-формах mdm.Matrix: компактной
+// {"x":3,"p":2,"data":[...]} или вложенными массивами. Размер тела запроса
+// и число элементов результата ограничены, а каждый запрос выполняется с
+// тайм-аутом, который отменяет вычисление
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"matrix/mdm"
+)
+
+// Значения Config по умолчанию
+const (
+	DefaultMaxBodyBytes      = 32 << 20
+	DefaultMaxResultElements = 1 << 24
+	DefaultTimeout           = 30 * time.Second
+)
+
+// Config задаёт ограничения и параметры выполнения сервера.
+// Нулевые поля означают значения по умолчанию
+type Config struct {
+	// MaxBodyBytes — наибольший размер тела запроса
+	MaxBodyBytes int64
+	// MaxResultElements — наибольшее число элементов результата
+	MaxResultElements int
+	// Timeout — предельное время обработки одного запроса
+	Timeout time.Duration
+	// Workers — число горутин общего пула; 0 — по числу процессоров
+	Workers int
+}
+
+// MultiplyRequest — тело запроса POST /v1/multiply
+type MultiplyRequest struct {
+	// Type — тип элементов: int8…uint64, float32, float64 (по умолчанию),
+	// complex64, complex128
+	Type   string `json:"type,omitempty"`
+	Lambda uint32 `json:"lambda"`
+	Mu     uint32 `json:"mu"`
+	// Nested — вернуть результат вложенными массивами
+	Nested bool            `json:"nested,omitempty"`
+	LHS    json.RawMessage `json:"lhs"`
+	RHS    json.RawMessage `json:"rhs"`
+}
+
+// Server обрабатывает HTTP-запросы на умножение. Умножения всех запросов
+// выполняются на общем пуле горутин; после использования вызовите Close
+type Server struct {
+	cfg     Config
+	pool    *mdm.Pool
+	mux     *http.ServeMux
+	metrics metrics
+}
+
+// New создаёт сервер с конфигурацией cfg
+func New(cfg Config) *Server {
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if cfg.MaxResultElements <= 0 {
+		cfg.MaxResultElements = DefaultMaxResultElements
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	s := &Server{cfg: cfg, pool: mdm.NewPool(cfg.Workers), mux: http.NewServeMux()}
+	s.metrics.codes = make(map[int]uint64)
+	s.mux.HandleFunc("POST /v1/multiply", s.handleMultiply)
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("GET /metrics", s.handleMetrics)
+	return s
+}
+
+// ServeHTTP реализует http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Close останавливает общий пул; выполняющиеся запросы должны быть
+// завершены заранее, например через http.Server.Shutdown
+func (s *Server) Close() {
+	s.pool.Close()
+}
+
+// multiplyFunc разбирает операнды запроса и умножает их
+type multiplyFunc func(s *Server, ctx context.Context, req *MultiplyRequest) ([]byte, error)
+
+// multipliers сопоставляет типам элементов реализации умножения
+var multipliers = map[string]multiplyFunc{
+	"int8": multiply[int8], "int16": multiply[int16], "int32": multiply[int32], "int64": multiply[int64],
+	"uint8": multiply[uint8], "uint16": multiply[uint16], "uint32": multiply[uint32], "uint64": multiply[uint64],
+	"float32": multiply[float32], "float64": multiply[float64],
+	"complex64": multiply[complex64], "complex128": multiply[complex128],
+}
+
+// Ошибки запроса, не относящиеся к mdm
+var (
+	errUnknownType   = errors.New("unknown element type")
+	errMissingMatrix = errors.New("lhs and rhs are required")
+	errResultTooBig  = errors.New("result is too large")
+)
+
+func (s *Server) handleMultiply(w http.ResponseWriter, r *http.Request) {
+	s.metrics.inFlight.Add(1)
+	defer s.metrics.inFlight.Add(-1)
+	start := time.Now()
+
+	var req MultiplyRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.cfg.MaxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		s.fail(w, fmt.Errorf("%w: %w", mdm.ErrInvalidFormat, err))
+		return
+	}
+	if req.Type == "" {
+		req.Type = "float64"
+	}
+	multiply, ok := multipliers[req.Type]
+	if !ok {
+		s.fail(w, fmt.Errorf("%w: %q", errUnknownType, req.Type))
+		return
+	}
+	if req.LHS == nil || req.RHS == nil {
+		s.fail(w, errMissingMatrix)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.Timeout)
+	defer cancel()
+	body, err := multiply(s, ctx, &req)
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	s.metrics.observe(time.Since(start))
+	s.reply(w, http.StatusOK, body)
+}
+
+func multiply[T mdm.Number](s *Server, ctx context.Context, req *MultiplyRequest) ([]byte, error) {
+	var lhs, rhs mdm.Matrix[T]
+	if err := lhs.UnmarshalJSON(req.LHS); err != nil {
+		return nil, fmt.Errorf("lhs: %w", err)
+	}
+	if err := rhs.UnmarshalJSON(req.RHS); err != nil {
+		return nil, fmt.Errorf("rhs: %w", err)
+	}
+	plan, err := newPlan(&lhs, &rhs, req.Lambda, req.Mu)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Size() > s.cfg.MaxResultElements {
+		return nil, fmt.Errorf("%w: %d elements, limit %d", errResultTooBig, plan.Size(), s.cfg.MaxResultElements)
+	}
+
+	// Ход вычисления сразу отражается в метриках
+	var reported int
+	opts := &mdm.Options{Pool: s.pool, Progress: func(done, _ int) {
+		s.metrics.elements.Add(uint64(done - reported))
+		reported = done
+	}}
+	result := plan.NewResult()
+	if err := plan.ParallelExecuteWith(ctx, &lhs, &rhs, result, opts); err != nil {
+		return nil, err
+	}
+	if req.Nested {
+		return result.MarshalNestedJSON()
+	}
+	return result.MarshalJSON()
+}
+
+// newPlan строит план умножения операндов. Порядок кубических операндов
+// проверяется до построения их форм, поэтому формы не материализуются
+// ради проверки
+func newPlan[T mdm.Number](lhs, rhs *mdm.Matrix[T], lambda, mu uint32) (*mdm.Plan[T], error) {
+	if lhs.IsCubic() && rhs.IsCubic() && lhs.X == rhs.X {
+		return mdm.NewPlan[T](lhs.X, lhs.P, rhs.P, lambda, mu)
+	}
+	return mdm.NewShapedPlan[T](lhs.Shape(), rhs.Shape(), lambda, mu)
+}
+
+// status сопоставляет ошибке код ответа
+func status(err error) int {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge), errors.Is(err, errResultTooBig), errors.Is(err, mdm.ErrSizeOverflow):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled), errors.Is(err, mdm.ErrPoolClosed):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, mdm.ErrDimensionMismatch), errors.Is(err, mdm.ErrInvalidLambdaMu):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func (s *Server) fail(w http.ResponseWriter, err error) {
+	body, _ := json.Marshal(map[string]string{"error": err.Error()})
+	s.reply(w, status(err), body)
+}
+
+func (s *Server) reply(w http.ResponseWriter, code int, body []byte) {
+	s.metrics.count(code)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(append(body, '\n'))
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"status":"ok"}` + "\n"))
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"matrix/mdm"
+)
+
+// newTestServer запускает сервер с конфигурацией cfg на httptest.Server
+func newTestServer(t *testing.T, cfg Config) *httptest.Server {
+	t.Helper()
+	srv := New(cfg)
+	ts := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		ts.Close()
+		srv.Close()
+	})
+	return ts
+}
+
+// post отправляет запрос и возвращает код ответа и тело
+func post(t *testing.T, ts *httptest.Server, body string) (int, string) {
+	t.Helper()
+	resp, err := http.Post(ts.URL+"/v1/multiply", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(b)
+}
+
+// TestMultiply тестирует совпадение ответа с mdm
+func TestMultiply(t *testing.T) {
+	ts := newTestServer(t, Config{})
+
+	lhs := mdm.CreateMatrix[float64](2, 3)
+	rhs := mdm.CreateMatrix[float64](2, 3)
+	for i := range lhs.Data {
+		lhs.Data[i] = float64(i) - 3.5
+		rhs.Data[i] = float64(i%3) * 0.5
+	}
+	a, _ := json.Marshal(lhs)
+	b, _ := rhs.MarshalNestedJSON()
+	code, body := post(t, ts, `{"lambda":1,"mu":1,"lhs":`+string(a)+`,"rhs":`+string(b)+`}`)
+	if code != http.StatusOK {
+		t.Fatalf("Status %d: %s", code, body)
+	}
+	var got mdm.Matrix[float64]
+	if err := json.Unmarshal([]byte(body), &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	expected := lhs.Multiplication(1, 1, rhs)
+	if got.X != expected.X || got.P != expected.P || !slices.Equal(got.Data, expected.Data) {
+		t.Errorf("Got %+v, expected %+v", got, expected)
+	}
+
+	code, body = post(t, ts, `{"type":"int64","mu":1,"nested":true,"lhs":[[1,2],[3,4]],"rhs":[[5,6],[7,8]]}`)
+	if code != http.StatusOK || strings.TrimSpace(body) != "[[19,22],[43,50]]" {
+		t.Errorf("Status %d: %s", code, body)
+	}
+
+	code, body = post(t, ts, `{"type":"complex128","mu":1,"lhs":["(0+1i)"],"rhs":["(0+1i)"]}`)
+	if code != http.StatusOK || strings.TrimSpace(body) != `{"x":1,"p":0,"data":["(-1+0i)"]}` {
+		t.Errorf("Status %d: %s", code, body)
+	}
+}
+
+// TestErrors тестирует коды ответов на неверные запросы
+func TestErrors(t *testing.T) {
+	ts := newTestServer(t, Config{MaxBodyBytes: 256, MaxResultElements: 10})
+	tests := []struct {
+		name string
+		body string
+		code int
+	}{
+		{"syntax", `{"lhs":`, http.StatusBadRequest},
+		{"unknown field", `{"lhs":[1],"rhs":[1],"alpha":1}`, http.StatusBadRequest},
+		{"type", `{"type":"int","lhs":[1],"rhs":[1]}`, http.StatusBadRequest},
+		{"missing", `{"lhs":[1]}`, http.StatusBadRequest},
+		{"data length", `{"lhs":{"x":2,"p":1,"data":[1]},"rhs":[1,2]}`, http.StatusBadRequest},
+		{"ragged", `{"lhs":[[1],[2,3]],"rhs":[1]}`, http.StatusBadRequest},
+		{"huge order", `{"lhs":{"x":1,"p":400000000,"data":[1]},"rhs":[1]}`, http.StatusBadRequest},
+		{"dimension", `{"mu":1,"lhs":[1,2],"rhs":[1,2,3]}`, http.StatusUnprocessableEntity},
+		{"lambda", `{"lambda":2,"lhs":[1,2],"rhs":[1,2]}`, http.StatusUnprocessableEntity},
+		{"result", `{"mu":0,"lhs":[1,2,3,4],"rhs":[1,2,3,4]}`, http.StatusRequestEntityTooLarge},
+		{"body", `{"lhs":[` + strings.Repeat("1,", 200) + `1],"rhs":[1]}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		code, body := post(t, ts, tt.body)
+		if code != tt.code {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.code, code, body)
+		}
+		var reply map[string]string
+		if err := json.Unmarshal([]byte(body), &reply); err != nil || reply["error"] == "" {
+			t.Errorf("%s: expected JSON error, got %q", tt.name, body)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/v1/multiply")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET /v1/multiply: expected 405, got %d", resp.StatusCode)
+	}
+}
+
+// TestTimeout тестирует отмену умножения по тайм-ауту запроса
+func TestTimeout(t *testing.T) {
+	ts := newTestServer(t, Config{Timeout: time.Nanosecond})
+	code, body := post(t, ts, `{"mu":1,"lhs":[[1,2],[3,4]],"rhs":[[5,6],[7,8]]}`)
+	if code != http.StatusServiceUnavailable || !strings.Contains(body, "deadline") {
+		t.Errorf("Expected 503 with deadline error, got %d: %s", code, body)
+	}
+}
+
+// TestHealthAndMetrics тестирует служебные конечные точки
+func TestHealthAndMetrics(t *testing.T) {
+	ts := newTestServer(t, Config{})
+
+	resp, err := http.Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("GET /healthz: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"ok"`) {
+		t.Errorf("/healthz: %d %s", resp.StatusCode, body)
+	}
+
+	post(t, ts, `{"mu":1,"lhs":[[1,2],[3,4]],"rhs":[[5,6],[7,8]]}`)
+	post(t, ts, `{"lhs":`)
+
+	resp, err = http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	for _, line := range []string{
+		`mmmatrix_requests_total{code="200"} 1`,
+		`mmmatrix_requests_total{code="400"} 1`,
+		`mmmatrix_requests_in_flight 0`,
+		`mmmatrix_result_elements_total 4`,
+		`mmmatrix_multiply_seconds_count 1`,
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("/metrics lacks %q:\n%s", line, body)
+		}
+	}
+}